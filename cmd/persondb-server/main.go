@@ -0,0 +1,116 @@
+// Command persondb-server shares a persons database over the network with
+// the persondb JSON REST API, for use with the provider's "http" backend.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+	"github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/server"
+)
+
+func main() {
+	var listen, database, actor string
+	var softDelete, insecure bool
+	var retention time.Duration
+
+	flag.StringVar(&listen, "listen", ":8080", "address to listen on")
+	flag.StringVar(&database, "database", "persons.db", "SQLite database filename")
+	flag.StringVar(&actor, "actor", "persondb-server", "actor recorded in the audit log as the author of the changes made through the API")
+	flag.BoolVar(&softDelete, "soft-delete", false, "mark deleted persons with a tombstone instead of removing them")
+	flag.DurationVar(&retention, "soft-delete-retention", 0, "permanently remove tombstones older than this duration, 0 keeps them forever")
+	flag.BoolVar(&insecure, "insecure", false, "serve the API without authentication when PERSONDB_SERVER_TOKEN is not set")
+	flag.Parse()
+
+	// The token is read from the environment so it does not show up in the
+	// process list.
+	token := os.Getenv("PERSONDB_SERVER_TOKEN")
+	if token == "" {
+		if !insecure {
+			log.Fatal("PERSONDB_SERVER_TOKEN is not set, set it or pass -insecure to serve the API without authentication")
+		}
+		log.Print("PERSONDB_SERVER_TOKEN is not set, the API is not authenticated")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	config := persondbclient.DefaultConfig(database)
+	config.SoftDelete = softDelete
+	store, err := persondbclient.NewClient(ctx, config)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	defer store.Close()
+
+	var purges sync.WaitGroup
+	if retention > 0 {
+		purges.Add(1)
+		go func() {
+			defer purges.Done()
+			purgeDeletedPersons(ctx, store, retention)
+		}()
+	}
+
+	srv := &http.Server{
+		Addr:              listen,
+		Handler:           server.New(store, token, actor),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	shutdown := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		shutdown <- srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("serving %s on %s", database, listen)
+	err = srv.ListenAndServe()
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err.Error())
+	}
+
+	// Wait for the in-flight requests and the purge to finish before the
+	// store is closed. When the requests do not finish in time the process
+	// exits without closing it, SQLite rolls back the open transactions.
+	if err := <-shutdown; err != nil {
+		log.Fatalf("shutting down: %s", err)
+	}
+	purges.Wait()
+}
+
+// purgeInterval is the time between two purges of the tombstones that are
+// past their retention.
+const purgeInterval = time.Hour
+
+// purgeDeletedPersons removes the tombstones older than retention at start-up
+// and every purgeInterval until ctx is done.
+func purgeDeletedPersons(ctx context.Context, store persondbclient.PersonStore, retention time.Duration) {
+	ctx = persondbclient.ContextWithActor(ctx, "persondb-server")
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+	for {
+		purged, err := store.PurgeDeletedPersons(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Printf("purging deleted persons: %s", err)
+		} else if purged > 0 {
+			log.Printf("purged %d deleted persons", purged)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,7 @@
+# Permanently remove the persons deleted more than 30 days ago, run with:
+#   terraform apply -invoke=action.persondb_purge_deleted_persons.monthly
+action "persondb_purge_deleted_persons" "monthly" {
+  config {
+    older_than = "720h"
+  }
+}
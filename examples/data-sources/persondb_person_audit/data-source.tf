@@ -0,0 +1,4 @@
+# List the change history of person with ID 1 in the database.
+data "persondb_person_audit" "wim" {
+  person_id = "1"
+}
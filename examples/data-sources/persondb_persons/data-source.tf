@@ -0,0 +1,23 @@
+# List the persons whose family name starts with "Van", ordered by family name.
+data "persondb_persons" "van" {
+  family_name_prefix = "Van"
+  order_by           = "family_name"
+}
+
+# List the persons of the platform team.
+data "persondb_persons" "platform" {
+  tags = {
+    team = "platform"
+  }
+}
+
+# List the second page of 10 persons.
+data "persondb_persons" "page_2" {
+  limit  = 10
+  offset = 10
+}
+
+# Output the display name of every listed person, keyed by person ID.
+output "van_persons" {
+  value = { for person in data.persondb_persons.van.persons : person.person_id => person.display_name }
+}
@@ -0,0 +1,4 @@
+# Returns "/person/1".
+output "id" {
+  value = provider::persondb::format_id("1")
+}
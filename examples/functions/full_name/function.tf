@@ -0,0 +1,9 @@
+# Returns "Wim Van den Wyngaert".
+output "full_name" {
+  value = provider::persondb::full_name(persondb_person.wim.name)
+}
+
+# Returns "Dr. Jo Peeters".
+output "full_name_parts" {
+  value = provider::persondb::full_name({ prefix = "Dr.", given = "Jo", family = "Peeters" })
+}
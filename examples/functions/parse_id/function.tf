@@ -0,0 +1,4 @@
+# Returns "1".
+output "person_id" {
+  value = provider::persondb::parse_id(persondb_person.wim.id)
+}
@@ -0,0 +1,13 @@
+# List all persons in the database.
+list "persondb_person" "all" {
+  provider = persondb
+}
+
+# List the persons whose family name starts with "Van".
+list "persondb_person" "van" {
+  provider = persondb
+
+  config {
+    family_name_prefix = "Van"
+  }
+}
@@ -2,3 +2,7 @@ $ terraform import persondb_person.wim '</person/{person_id}>'
 
 # Importing a person by specifying the numeric identifier.
 # terraform import persondb_person.wim '/person/1'
+
+# The plain person ID and the URN-style ID are accepted as well.
+# terraform import persondb_person.wim '1'
+# terraform import persondb_person.wim 'persondb:person:1'
@@ -1,6 +1,43 @@
 # Create a person in the database.
 resource "persondb_person" "wim" {
-  person_id  = "1"
-  last_name  = "Van den Wyngaert"
-  first_name = "Wim"
+  person_id = "1"
+  name = {
+    given  = "Wim"
+    family = "Van den Wyngaert"
+  }
+}
+
+# Create a person with contact details.
+resource "persondb_person" "jan" {
+  person_id = "2"
+  name = {
+    prefix    = "Dr."
+    given     = "Johannes"
+    middle    = "Maria"
+    family    = "Peeters"
+    preferred = "Jan"
+  }
+  email         = "jan.peeters@example.com"
+  birth_date    = "1990-12-31"
+  phone_numbers = ["+32 470 12 34 56"]
+
+  tags = {
+    team        = "platform"
+    cost_center = "cc-1234"
+  }
+
+  address {
+    type        = "home"
+    street      = "Kerkstraat 1"
+    city        = "Antwerpen"
+    postal_code = "2000"
+    country     = "BE"
+  }
+
+  address {
+    type    = "work"
+    street  = "Grote Markt 5"
+    city    = "Brussel"
+    country = "BE"
+  }
 }
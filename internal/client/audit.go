@@ -0,0 +1,161 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// Audit operations.
+const (
+	AuditOperationCreate  = "create"
+	AuditOperationUpdate  = "update"
+	AuditOperationDelete  = "delete"
+	AuditOperationRestore = "restore"
+	AuditOperationPurge   = "purge"
+)
+
+// DefaultActor is recorded in the audit log when no actor is set on the
+// context.
+const DefaultActor = "unknown"
+
+// AuditEntry is one change of a person in the audit log. OldValues is nil for
+// a create or restore and NewValues is nil for a delete or purge.
+// ClaimedActor is the actor a client of a persondb server sent along with
+// the change, it is not verified: Actor is the author the server
+// authenticated.
+type AuditEntry struct {
+	ID           int64     `json:"id"`
+	PersonID     string    `json:"person_id"`
+	Timestamp    time.Time `json:"timestamp"`
+	Operation    string    `json:"operation"`
+	Actor        string    `json:"actor"`
+	ClaimedActor string    `json:"claimed_actor,omitempty"`
+	OldValues    *Person   `json:"old_values"`
+	NewValues    *Person   `json:"new_values"`
+}
+
+type actorContextKey struct{}
+
+type claimedActorContextKey struct{}
+
+// ContextWithActor returns a context that records actor as the author of
+// the changes made with it.
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// actorFromContext returns the actor set with ContextWithActor, or
+// DefaultActor.
+func actorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorContextKey{}).(string)
+	if actor == "" {
+		return DefaultActor
+	}
+	return actor
+}
+
+// ContextWithClaimedActor returns a context that records actor as the
+// unverified author claimed by the client of a persondb server.
+func ContextWithClaimedActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, claimedActorContextKey{}, actor)
+}
+
+// claimedActorFromContext returns the actor set with
+// ContextWithClaimedActor, or an empty string.
+func claimedActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(claimedActorContextKey{}).(string)
+	return actor
+}
+
+// newAuditEntry builds the audit entry for a change made with ctx.
+func newAuditEntry(ctx context.Context, operation string, oldValues, newValues *Person) AuditEntry {
+	entry := AuditEntry{
+		Timestamp:    time.Now().UTC(),
+		Operation:    operation,
+		Actor:        actorFromContext(ctx),
+		ClaimedActor: claimedActorFromContext(ctx),
+		OldValues:    oldValues,
+		NewValues:    newValues,
+	}
+	if oldValues != nil {
+		entry.PersonID = oldValues.PersonID
+	} else if newValues != nil {
+		entry.PersonID = newValues.PersonID
+	}
+	return entry
+}
+
+// writeAudit inserts the audit entry of a change in the transaction making
+// the change.
+func writeAudit(ctx context.Context, tx *sql.Tx, entry AuditEntry) error {
+	oldValues, err := marshalAuditValues(entry.OldValues)
+	if err != nil {
+		return err
+	}
+	newValues, err := marshalAuditValues(entry.NewValues)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, "INSERT INTO persons_audit (person_id, changed_at, operation, actor, claimed_actor, old_values, new_values) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		entry.PersonID, entry.Timestamp.Format(time.RFC3339Nano), entry.Operation, entry.Actor, entry.ClaimedActor, oldValues, newValues)
+	return classifyError(err)
+}
+
+func marshalAuditValues(person *Person) (sql.NullString, error) {
+	if person == nil {
+		return sql.NullString{}, nil
+	}
+	content, err := json.Marshal(person)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(content), Valid: true}, nil
+}
+
+func unmarshalAuditValues(value sql.NullString) (*Person, error) {
+	if !value.Valid {
+		return nil, nil
+	}
+	var person Person
+	if err := json.Unmarshal([]byte(value.String), &person); err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+func (c *Client) ListAuditEntries(ctx context.Context, personID string) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	err := c.withRetry(ctx, "list audit entries", func() error {
+		entries = nil
+		rows, err := c.db.QueryContext(ctx, "SELECT audit_id, changed_at, operation, actor, claimed_actor, old_values, new_values FROM persons_audit WHERE person_id = ? ORDER BY audit_id", personID)
+		if err != nil {
+			return classifyError(err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			entry := AuditEntry{PersonID: personID}
+			var changedAt string
+			var oldValues, newValues sql.NullString
+			if err := rows.Scan(&entry.ID, &changedAt, &entry.Operation, &entry.Actor, &entry.ClaimedActor, &oldValues, &newValues); err != nil {
+				return classifyError(err)
+			}
+			if entry.Timestamp, err = time.Parse(time.RFC3339Nano, changedAt); err != nil {
+				return err
+			}
+			if entry.OldValues, err = unmarshalAuditValues(oldValues); err != nil {
+				return err
+			}
+			if entry.NewValues, err = unmarshalAuditValues(newValues); err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		return classifyError(rows.Err())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -1,117 +1,352 @@
 package client
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"net/url"
+	"strconv"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// timestampLayout is the fixed width UTC layout of stored timestamps, so they
+// sort lexically in SQL.
+const timestampLayout = "2006-01-02T15:04:05.000000Z"
+
+// Options holds the behaviour settings shared by all backends.
+type Options struct {
+	// SoftDelete marks deleted persons with a tombstone instead of removing
+	// them, so they can be restored by creating them again.
+	SoftDelete bool
+}
+
+// Config holds the settings used to open the persons database.
+type Config struct {
+	Options
+
+	// DatabaseFilename is the path of the SQLite database file.
+	DatabaseFilename string
+
+	// MaxOpenConnections limits the number of open connections in the pool.
+	// Zero means unlimited.
+	MaxOpenConnections int
+
+	// MaxIdleConnections limits the number of idle connections kept in the pool.
+	MaxIdleConnections int
+
+	// ConnectionMaxLifetime is the maximum amount of time a connection may be
+	// reused. Zero means connections are reused forever.
+	ConnectionMaxLifetime time.Duration
+
+	// BusyTimeout is how long SQLite waits on a locked database before
+	// returning an error (PRAGMA busy_timeout).
+	BusyTimeout time.Duration
+
+	// JournalMode is the SQLite journal mode (PRAGMA journal_mode).
+	JournalMode string
+
+	// ForeignKeys enables foreign key enforcement (PRAGMA foreign_keys).
+	ForeignKeys bool
+
+	// MaxRetries is the number of times an operation is retried when the
+	// database is busy or locked.
+	MaxRetries int
+
+	// RetryMaxWait caps the exponential backoff between two retries.
+	RetryMaxWait time.Duration
+}
+
+// DefaultConfig returns the default settings for the given database file.
+func DefaultConfig(databaseFilename string) Config {
+	return Config{
+		DatabaseFilename:   databaseFilename,
+		MaxOpenConnections: 4,
+		MaxIdleConnections: 4,
+		BusyTimeout:        5 * time.Second,
+		JournalMode:        "WAL",
+		ForeignKeys:        true,
+		MaxRetries:         5,
+		RetryMaxWait:       2 * time.Second,
+	}
+}
+
+// dataSourceName builds the go-sqlite3 DSN, so the PRAGMAs are applied to
+// every connection the pool opens.
+func (c Config) dataSourceName() string {
+	params := url.Values{}
+	params.Set("_busy_timeout", strconv.FormatInt(c.BusyTimeout.Milliseconds(), 10))
+	if c.JournalMode != "" {
+		params.Set("_journal_mode", c.JournalMode)
+	}
+	if c.ForeignKeys {
+		params.Set("_foreign_keys", "on")
+	} else {
+		params.Set("_foreign_keys", "off")
+	}
+	// Take the write lock when a transaction starts, so concurrent writers
+	// wait on busy_timeout instead of failing on a lock upgrade.
+	params.Set("_txlock", "immediate")
+	return c.DatabaseFilename + "?" + params.Encode()
+}
+
+// Client is the SQLite implementation of PersonStore. It owns one long-lived
+// connection pool for the lifetime of the provider.
 type Client struct {
 	CustomDatabase string
+	db             *sql.DB
+	config         Config
 }
 
-func NewClient(databaseFilename string) (*Client, error) {
+func NewClient(ctx context.Context, config Config) (*Client, error) {
+	db, err := sql.Open("sqlite3", config.dataSourceName())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(config.MaxOpenConnections)
+	db.SetMaxIdleConns(config.MaxIdleConnections)
+	db.SetConnMaxLifetime(config.ConnectionMaxLifetime)
+
 	c := &Client{
-		CustomDatabase: databaseFilename,
+		CustomDatabase: config.DatabaseFilename,
+		db:             db,
+		config:         config,
 	}
-	err := c.initDB()
+	err = c.withRetry(ctx, "migrate", func() error {
+		return classifyError(c.migrate(ctx))
+	})
 	if err != nil {
+		db.Close()
 		return nil, err
 	}
 	return c, nil
 }
 
-func (c *Client) initDB() error {
-	db, err := sql.Open("sqlite3", c.CustomDatabase)
-	if err != nil {
-		return err
-	}
-	defer db.Close()
-	sqlStmt := `
-	CREATE TABLE IF NOT EXISTS persons (
-		person_id TEXT NOT NULL PRIMARY KEY,
-		last_name TEXT NOT NULL,
-		first_name TEXT
-	);
-	`
-	_, err = db.Exec(sqlStmt)
-	if err != nil {
-		return err
-	}
-	return nil
+// Close releases the connection pool.
+func (c *Client) Close() error {
+	return c.db.Close()
+}
+
+// queryer is implemented by *sql.DB and *sql.Tx, so the same queries run
+// inside and outside of transactions.
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withTx runs fn in a transaction, retried as a whole when the database is
+// locked. fn must return classified errors.
+func (c *Client) withTx(ctx context.Context, operation string, fn func(tx *sql.Tx) error) error {
+	return c.withRetry(ctx, operation, func() error {
+		tx, err := c.db.BeginTx(ctx, nil)
+		if err != nil {
+			return classifyError(err)
+		}
+		defer tx.Rollback()
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return classifyError(tx.Commit())
+	})
 }
 
-func (c *Client) CreatePerson(personID, lastName, firstName string) error {
-	db, err := sql.Open("sqlite3", c.CustomDatabase)
+// readPerson reads one person row, tombstoned persons are not found.
+func readPerson(ctx context.Context, q queryer, personID string) (*Person, error) {
+	persons := []Person{{PersonID: personID}}
+	person := &persons[0]
+	err := q.QueryRowContext(ctx, "SELECT last_name, first_name, name_prefix, middle_name, name_suffix, preferred_name, email, birth_date, version FROM persons WHERE person_id = ? AND deleted_at IS NULL", personID).
+		Scan(&person.LastName, &person.FirstName, &person.NamePrefix, &person.MiddleName, &person.NameSuffix, &person.PreferredName, &person.Email, &person.BirthDate, &person.Version)
 	if err != nil {
-		return err
+		return nil, classifyError(err)
 	}
-	defer db.Close()
-	_, err = db.Exec("INSERT INTO persons (person_id, last_name, first_name) VALUES (?, ?, ?)", personID, lastName, firstName)
-	if err != nil {
-		return err
+	if err := loadPersonDetails(ctx, q, persons); err != nil {
+		return nil, err
 	}
-	return nil
+	return person, nil
 }
 
-func (c *Client) ReadPerson(personID string) (string, string, error) {
-	db, err := sql.Open("sqlite3", c.CustomDatabase)
+func (c *Client) CreatePerson(ctx context.Context, person Person) (*Person, error) {
+	person = normalizePerson(person)
+	err := c.withTx(ctx, "create person", func(tx *sql.Tx) error {
+		if err := checkEmailAvailable(ctx, tx, person); err != nil {
+			return err
+		}
+		var version int64
+		var deletedAt sql.NullString
+		err := tx.QueryRowContext(ctx, "SELECT version, deleted_at FROM persons WHERE person_id = ?", person.PersonID).Scan(&version, &deletedAt)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			person.Version = 1
+			_, err = tx.ExecContext(ctx, "INSERT INTO persons (person_id, last_name, first_name, name_prefix, middle_name, name_suffix, preferred_name, email, birth_date, version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+				person.PersonID, person.LastName, person.FirstName, person.NamePrefix, person.MiddleName, person.NameSuffix, person.PreferredName, person.Email, person.BirthDate, person.Version)
+			if err != nil {
+				return classifyError(err)
+			}
+			if err := savePersonDetails(ctx, tx, person); err != nil {
+				return err
+			}
+			return writeAudit(ctx, tx, newAuditEntry(ctx, AuditOperationCreate, nil, &person))
+		case err != nil:
+			return classifyError(err)
+		case !deletedAt.Valid:
+			return ErrPersonAlreadyExists
+		default:
+			// Restore the tombstoned person with the new values
+			person.Version = version + 1
+			_, err = tx.ExecContext(ctx, "UPDATE persons SET last_name = ?, first_name = ?, name_prefix = ?, middle_name = ?, name_suffix = ?, preferred_name = ?, email = ?, birth_date = ?, version = ?, deleted_at = NULL WHERE person_id = ?",
+				person.LastName, person.FirstName, person.NamePrefix, person.MiddleName, person.NameSuffix, person.PreferredName, person.Email, person.BirthDate, person.Version, person.PersonID)
+			if err != nil {
+				return classifyError(err)
+			}
+			if err := savePersonDetails(ctx, tx, person); err != nil {
+				return err
+			}
+			return writeAudit(ctx, tx, newAuditEntry(ctx, AuditOperationRestore, nil, &person))
+		}
+	})
 	if err != nil {
-		return "", "", err
-	}
-	defer db.Close()
-	var lastName, firstName string
-	err = db.QueryRow("SELECT last_name, first_name FROM persons WHERE person_id = ?", personID).Scan(&lastName, &firstName)
-	if err != nil {
-		return "", "", err
+		return nil, err
 	}
-	return lastName, firstName, nil
+	return &person, nil
 }
 
-func (c *Client) UpdatePerson(personID, lastName, firstName string) error {
-	db, err := sql.Open("sqlite3", c.CustomDatabase)
-	if err != nil {
+func (c *Client) ReadPerson(ctx context.Context, personID string) (*Person, error) {
+	var person *Person
+	err := c.withRetry(ctx, "read person", func() error {
+		var err error
+		person, err = readPerson(ctx, c.db, personID)
 		return err
-	}
-	defer db.Close()
-	_, err = db.Exec("UPDATE persons SET last_name = ?, first_name = ? WHERE person_id = ?", lastName, firstName, personID)
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	return person, nil
 }
 
-func (c *Client) DeletePerson(personID string) error {
-	db, err := sql.Open("sqlite3", c.CustomDatabase)
+func (c *Client) UpdatePerson(ctx context.Context, person Person) (*Person, error) {
+	person = normalizePerson(person)
+	err := c.withTx(ctx, "update person", func(tx *sql.Tx) error {
+		current, err := readPerson(ctx, tx, person.PersonID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(person, current); err != nil {
+			return err
+		}
+		if err := checkEmailAvailable(ctx, tx, person); err != nil {
+			return err
+		}
+		person.Version = current.Version + 1
+		_, err = tx.ExecContext(ctx, "UPDATE persons SET last_name = ?, first_name = ?, name_prefix = ?, middle_name = ?, name_suffix = ?, preferred_name = ?, email = ?, birth_date = ?, version = ? WHERE person_id = ?",
+			person.LastName, person.FirstName, person.NamePrefix, person.MiddleName, person.NameSuffix, person.PreferredName, person.Email, person.BirthDate, person.Version, person.PersonID)
+		if err != nil {
+			return classifyError(err)
+		}
+		if err := savePersonDetails(ctx, tx, person); err != nil {
+			return err
+		}
+		return writeAudit(ctx, tx, newAuditEntry(ctx, AuditOperationUpdate, current, &person))
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer db.Close()
-	result, err := db.Exec("DELETE FROM persons WHERE person_id = ?", personID)
+	return &person, nil
+}
+
+func (c *Client) DeletePerson(ctx context.Context, personID string) error {
+	return c.withTx(ctx, "delete person", func(tx *sql.Tx) error {
+		current, err := readPerson(ctx, tx, personID)
+		if err != nil {
+			return err
+		}
+		if c.config.SoftDelete {
+			_, err = tx.ExecContext(ctx, "UPDATE persons SET deleted_at = ?, version = version + 1 WHERE person_id = ?",
+				time.Now().UTC().Format(timestampLayout), personID)
+		} else {
+			if err := deletePersonDetails(ctx, tx, personID); err != nil {
+				return err
+			}
+			_, err = tx.ExecContext(ctx, "DELETE FROM persons WHERE person_id = ?", personID)
+		}
+		if err != nil {
+			return classifyError(err)
+		}
+		return writeAudit(ctx, tx, newAuditEntry(ctx, AuditOperationDelete, current, nil))
+	})
+}
+
+func (c *Client) CheckPersonExists(ctx context.Context, personID string) (bool, error) {
+	var exists bool
+	err := c.withRetry(ctx, "check person exists", func() error {
+		err := c.db.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM persons WHERE person_id = ? AND deleted_at IS NULL)", personID).Scan(&exists)
+		return classifyError(err)
+	})
 	if err != nil {
-		return err
+		return false, err
 	}
-	rowsAffected, err := result.RowsAffected()
+	return exists, nil
+}
+
+func (c *Client) PurgeDeletedPersons(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := c.withTx(ctx, "purge deleted persons", func(tx *sql.Tx) error {
+		purged = 0
+		tombstones, err := readTombstones(ctx, tx, deletedBefore)
+		if err != nil {
+			return err
+		}
+		for i := range tombstones {
+			tombstone := &tombstones[i]
+			if err := deletePersonDetails(ctx, tx, tombstone.PersonID); err != nil {
+				return err
+			}
+			if _, err := tx.ExecContext(ctx, "DELETE FROM persons WHERE person_id = ?", tombstone.PersonID); err != nil {
+				return classifyError(err)
+			}
+			if err := writeAudit(ctx, tx, newAuditEntry(ctx, AuditOperationPurge, tombstone, nil)); err != nil {
+				return err
+			}
+			purged++
+		}
+		return nil
+	})
 	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return errors.New("person not found in the database")
+		return 0, err
 	}
-	return nil
+	return purged, nil
 }
 
-func (c *Client) CheckPersonExists(personID string) (bool, error) {
-	db, err := sql.Open("sqlite3", c.CustomDatabase)
+// readTombstones reads the persons that were soft deleted before
+// deletedBefore, with their details.
+func readTombstones(ctx context.Context, tx *sql.Tx, deletedBefore time.Time) ([]Person, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT person_id, last_name, first_name, name_prefix, middle_name, name_suffix, preferred_name, email, birth_date, version, deleted_at FROM persons WHERE deleted_at IS NOT NULL AND deleted_at < ? ORDER BY person_id",
+		deletedBefore.UTC().Format(timestampLayout))
 	if err != nil {
-		return false, err
+		return nil, classifyError(err)
 	}
-	defer db.Close()
-	var exists bool
-	err = db.QueryRow("SELECT EXISTS(SELECT 1 FROM persons WHERE person_id = ?)", personID).Scan(&exists)
-	if err != nil {
-		return false, err
+	defer rows.Close()
+	var persons []Person
+	for rows.Next() {
+		var person Person
+		var deletedAt string
+		if err := rows.Scan(&person.PersonID, &person.LastName, &person.FirstName, &person.NamePrefix, &person.MiddleName, &person.NameSuffix, &person.PreferredName, &person.Email, &person.BirthDate, &person.Version, &deletedAt); err != nil {
+			return nil, classifyError(err)
+		}
+		deletedAtValue, err := time.Parse(timestampLayout, deletedAt)
+		if err != nil {
+			return nil, err
+		}
+		person.DeletedAt = &deletedAtValue
+		persons = append(persons, person)
 	}
-	return exists, nil
+	if err := rows.Err(); err != nil {
+		return nil, classifyError(err)
+	}
+	// The details are read in the same transaction, release the rows first
+	rows.Close()
+	if err := loadPersonDetails(ctx, tx, persons); err != nil {
+		return nil, err
+	}
+	return persons, nil
 }
@@ -0,0 +1,209 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestClient opens a client on a new database file in a temporary
+// directory.
+func newTestClient(t *testing.T, databaseFilename string) *Client {
+	t.Helper()
+	c, err := NewClient(context.Background(), DefaultConfig(databaseFilename))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+func TestUpdatePersonDeletedOutOfBand(t *testing.T) {
+	ctx := context.Background()
+	databaseFilename := filepath.Join(t.TempDir(), "persons.db")
+	c := newTestClient(t, databaseFilename)
+
+	created, err := c.CreatePerson(ctx, Person{PersonID: "1", LastName: "Peeters", FirstName: "Jan"})
+	if err != nil {
+		t.Fatalf("CreatePerson: %v", err)
+	}
+
+	// Another process removes the person behind the back of the first client
+	other := newTestClient(t, databaseFilename)
+	if err := other.DeletePerson(ctx, "1"); err != nil {
+		t.Fatalf("DeletePerson: %v", err)
+	}
+
+	_, err = c.UpdatePerson(ctx, Person{PersonID: "1", LastName: "Janssens", Version: created.Version})
+	if !errors.Is(err, ErrPersonNotFound) {
+		t.Fatalf("UpdatePerson: got error %v, want %v", err, ErrPersonNotFound)
+	}
+}
+
+func TestDeletePersonAbsent(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, filepath.Join(t.TempDir(), "persons.db"))
+
+	err := c.DeletePerson(ctx, "1")
+	if !errors.Is(err, ErrPersonNotFound) {
+		t.Fatalf("DeletePerson: got error %v, want %v", err, ErrPersonNotFound)
+	}
+}
+
+func TestPurgeDeletedPersonsAudit(t *testing.T) {
+	ctx := ContextWithActor(context.Background(), "janitor")
+	databaseFilename := filepath.Join(t.TempDir(), "persons.db")
+	config := DefaultConfig(databaseFilename)
+	config.SoftDelete = true
+
+	testCases := map[string]func(t *testing.T) PersonStore{
+		"sqlite": func(t *testing.T) PersonStore {
+			c, err := NewClient(ctx, config)
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+			t.Cleanup(func() { c.Close() })
+			return c
+		},
+		"memory": func(t *testing.T) PersonStore {
+			return NewMemoryStore(Options{SoftDelete: true})
+		},
+	}
+
+	for name, newStore := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			if _, err := store.CreatePerson(ctx, Person{PersonID: "1", LastName: "Peeters", PhoneNumbers: []string{"+32 470 12 34 56"}}); err != nil {
+				t.Fatalf("CreatePerson: %v", err)
+			}
+			if err := store.DeletePerson(ctx, "1"); err != nil {
+				t.Fatalf("DeletePerson: %v", err)
+			}
+
+			purged, err := store.PurgeDeletedPersons(ctx, time.Now().Add(time.Minute))
+			if err != nil || purged != 1 {
+				t.Fatalf("PurgeDeletedPersons: got %d, %v, want 1", purged, err)
+			}
+
+			entries, err := store.ListAuditEntries(ctx, "1")
+			if err != nil {
+				t.Fatalf("ListAuditEntries: %v", err)
+			}
+			if len(entries) != 3 {
+				t.Fatalf("ListAuditEntries: got %d entries, want 3", len(entries))
+			}
+			entry := entries[2]
+			if entry.Operation != AuditOperationPurge || entry.Actor != "janitor" || entry.NewValues != nil {
+				t.Errorf("purge entry: got %+v", entry)
+			}
+			if entry.OldValues == nil || entry.OldValues.LastName != "Peeters" || len(entry.OldValues.PhoneNumbers) != 1 || entry.OldValues.DeletedAt == nil {
+				t.Errorf("purge entry old values: got %+v, want the tombstone", entry.OldValues)
+			}
+		})
+	}
+}
+
+func TestAuditClaimedActor(t *testing.T) {
+	ctx := ContextWithClaimedActor(ContextWithActor(context.Background(), "api"), "wim")
+	c := newTestClient(t, filepath.Join(t.TempDir(), "persons.db"))
+
+	if _, err := c.CreatePerson(ctx, Person{PersonID: "1", LastName: "Peeters"}); err != nil {
+		t.Fatalf("CreatePerson: %v", err)
+	}
+
+	entries, err := c.ListAuditEntries(ctx, "1")
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Actor != "api" || entries[0].ClaimedActor != "wim" {
+		t.Errorf("ListAuditEntries: got %+v, want actor \"api\" claimed \"wim\"", entries)
+	}
+}
+
+func TestListPersonsMoreThanVariableLimit(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t, filepath.Join(t.TempDir(), "persons.db"))
+
+	// More persons than SQLite accepts bind variables in one query, inserted
+	// in one transaction as creating them one by one is slow
+	count := 33000
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("BeginTx: %v", err)
+	}
+	for i := range count {
+		person := Person{PersonID: fmt.Sprintf("%05d", i), LastName: "Peeters", PhoneNumbers: []string{fmt.Sprintf("+32 470 %05d", i)}}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO persons (person_id, last_name, first_name) VALUES (?, ?, '')", person.PersonID, person.LastName); err != nil {
+			t.Fatalf("insert person: %v", err)
+		}
+		if err := savePersonDetails(ctx, tx, person); err != nil {
+			t.Fatalf("savePersonDetails: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+
+	persons, err := c.ListPersons(ctx, ListFilter{})
+	if err != nil {
+		t.Fatalf("ListPersons: %v", err)
+	}
+	if len(persons) != count {
+		t.Fatalf("ListPersons: got %d persons, want %d", len(persons), count)
+	}
+	for i, person := range persons {
+		want := fmt.Sprintf("+32 470 %05d", i)
+		if len(person.PhoneNumbers) != 1 || person.PhoneNumbers[0] != want {
+			t.Errorf("person %s: got phone numbers %v, want [%s]", person.PersonID, person.PhoneNumbers, want)
+		}
+	}
+}
+
+func TestUpdatePersonVersionConflict(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := map[string]func(t *testing.T) PersonStore{
+		"sqlite": func(t *testing.T) PersonStore {
+			return newTestClient(t, filepath.Join(t.TempDir(), "persons.db"))
+		},
+		"memory": func(t *testing.T) PersonStore {
+			return NewMemoryStore(Options{})
+		},
+	}
+
+	for name, newStore := range testCases {
+		t.Run(name, func(t *testing.T) {
+			store := newStore(t)
+			created, err := store.CreatePerson(ctx, Person{PersonID: "1", LastName: "Peeters"})
+			if err != nil {
+				t.Fatalf("CreatePerson: %v", err)
+			}
+
+			updated, err := store.UpdatePerson(ctx, Person{PersonID: "1", LastName: "Janssens", Version: created.Version})
+			if err != nil {
+				t.Fatalf("UpdatePerson: %v", err)
+			}
+			if updated.Version != created.Version+1 {
+				t.Errorf("UpdatePerson: got version %d, want %d", updated.Version, created.Version+1)
+			}
+
+			// An update based on the version before the previous update conflicts
+			_, err = store.UpdatePerson(ctx, Person{PersonID: "1", LastName: "Maes", Version: created.Version})
+			if !errors.Is(err, ErrVersionConflict) {
+				t.Fatalf("UpdatePerson with a stale version: got error %v, want %v", err, ErrVersionConflict)
+			}
+
+			// A zero version skips the check
+			if _, err := store.UpdatePerson(ctx, Person{PersonID: "1", LastName: "Maes"}); err != nil {
+				t.Fatalf("UpdatePerson without a version: %v", err)
+			}
+			read, err := store.ReadPerson(ctx, "1")
+			if err != nil || read.LastName != "Maes" || read.Version != updated.Version+1 {
+				t.Errorf("ReadPerson: got %+v, %v, want last name \"Maes\" version %d", read, err, updated.Version+1)
+			}
+		})
+	}
+}
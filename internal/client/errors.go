@@ -0,0 +1,56 @@
+package client
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// Errors returned by the client. Callers should compare with errors.Is, the
+// returned errors wrap the underlying database error where there is one.
+var (
+	ErrPersonNotFound      = errors.New("person not found in the database")
+	ErrPersonAlreadyExists = errors.New("person already exists in the database")
+	ErrVersionConflict     = errors.New("person was changed since it was last read")
+	ErrDatabaseLocked      = errors.New("database is locked")
+	ErrDatabaseCorrupt     = errors.New("database is corrupt or not a database")
+	ErrInvalidFilter       = errors.New("invalid list filter")
+	ErrEmailAlreadyExists  = errors.New("email is already used by another person")
+)
+
+// classifyError maps driver errors onto the client sentinel errors.
+func classifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPersonNotFound
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		switch {
+		case sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked:
+			return fmt.Errorf("%w: %w", ErrDatabaseLocked, err)
+		case sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey && strings.HasSuffix(sqliteErr.Error(), ": persons.person_id"):
+			return fmt.Errorf("%w: %w", ErrPersonAlreadyExists, err)
+		case sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique && strings.Contains(sqliteErr.Error(), "persons_email"):
+			return fmt.Errorf("%w: %w", ErrEmailAlreadyExists, err)
+		case sqliteErr.Code == sqlite3.ErrCorrupt || sqliteErr.Code == sqlite3.ErrNotADB:
+			return fmt.Errorf("%w: %w", ErrDatabaseCorrupt, err)
+		}
+	}
+	return err
+}
+
+// checkVersion returns ErrVersionConflict when the update was based on an
+// older version of the person than the current one. A zero version skips
+// the check.
+func checkVersion(update Person, current *Person) error {
+	if update.Version != 0 && update.Version != current.Version {
+		return fmt.Errorf("%w: expected version %d, found version %d", ErrVersionConflict, update.Version, current.Version)
+	}
+	return nil
+}
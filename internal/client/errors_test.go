@@ -0,0 +1,65 @@
+package client
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestClassifyErrorPrimaryKey(t *testing.T) {
+	c := newTestClient(t, filepath.Join(t.TempDir(), "persons.db"))
+
+	testCases := map[string]struct {
+		statement string
+		want      error
+	}{
+		"person": {
+			statement: "INSERT INTO persons (person_id, last_name) VALUES ('1', 'Janssens')",
+			want:      ErrPersonAlreadyExists,
+		},
+		"tag": {
+			statement: "INSERT INTO person_tags (person_id, key, value) VALUES ('1', 'team', 'hr')",
+		},
+		"migration": {
+			statement: "INSERT INTO schema_migrations (version, name, applied_at) VALUES (1, 'create_persons', '')",
+		},
+	}
+
+	if _, err := c.db.Exec("INSERT INTO persons (person_id, last_name) VALUES ('1', 'Peeters')"); err != nil {
+		t.Fatalf("inserting a person: %v", err)
+	}
+	if _, err := c.db.Exec("INSERT INTO person_tags (person_id, key, value) VALUES ('1', 'team', 'platform')"); err != nil {
+		t.Fatalf("inserting a tag: %v", err)
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.db.Exec(testCase.statement)
+			if err == nil {
+				t.Fatal("expected a primary key violation")
+			}
+			err = classifyError(err)
+			if testCase.want != nil {
+				if !errors.Is(err, testCase.want) {
+					t.Errorf("got error %v, want %v", err, testCase.want)
+				}
+				return
+			}
+			if errors.Is(err, ErrPersonAlreadyExists) {
+				t.Errorf("got error %v, must not be reported as an existing person", err)
+			}
+		})
+	}
+}
+
+func TestClassifyErrorLocked(t *testing.T) {
+	for _, code := range []sqlite3.ErrNo{sqlite3.ErrBusy, sqlite3.ErrLocked} {
+		t.Run(code.Error(), func(t *testing.T) {
+			if err := classifyError(sqlite3.Error{Code: code}); !errors.Is(err, ErrDatabaseLocked) {
+				t.Errorf("got error %v, want %v", err, ErrDatabaseLocked)
+			}
+		})
+	}
+}
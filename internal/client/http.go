@@ -0,0 +1,255 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is the error body returned by the persondb server.
+type APIError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// apiErrorResponse wraps APIError in the JSON error envelope.
+type apiErrorResponse struct {
+	Error APIError `json:"error"`
+}
+
+// Error codes of the REST API, each code maps onto one sentinel error.
+var apiErrorCodes = map[string]error{
+	"person_not_found":      ErrPersonNotFound,
+	"person_already_exists": ErrPersonAlreadyExists,
+	"version_conflict":      ErrVersionConflict,
+	"database_locked":       ErrDatabaseLocked,
+	"database_corrupt":      ErrDatabaseCorrupt,
+	"invalid_filter":        ErrInvalidFilter,
+	"email_already_exists":  ErrEmailAlreadyExists,
+}
+
+// ErrorCode returns the REST API error code for err, or "internal_error" when
+// err does not wrap one of the sentinel errors.
+func ErrorCode(err error) string {
+	for code, sentinel := range apiErrorCodes {
+		if errors.Is(err, sentinel) {
+			return code
+		}
+	}
+	return "internal_error"
+}
+
+// ActorHeader is the request header carrying the actor, the persondb server
+// records it as the unverified claimed actor in the audit log.
+const ActorHeader = "X-PersonDB-Actor"
+
+// DefaultHTTPTimeout bounds a request to the persondb server, so a stalled
+// server cannot block callers without a context deadline forever.
+const DefaultHTTPTimeout = 30 * time.Second
+
+// HTTPStore is a PersonStore that talks to a persondb server over its JSON
+// REST API, so several Terraform workspaces can share one database.
+type HTTPStore struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// NewHTTPStore returns a store for the persondb server at endpoint. Every
+// request is bounded by timeout, zero means no timeout.
+func NewHTTPStore(endpoint, token string, timeout time.Duration) (*HTTPStore, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("endpoint must be an http or https URL, got: %s", endpoint)
+	}
+	return &HTTPStore{
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// personURL returns the URL of a person, or of the persons collection when
+// personID is empty.
+func (h *HTTPStore) personURL(personID string) string {
+	if personID == "" {
+		return h.endpoint + "/v1/persons"
+	}
+	return h.endpoint + "/v1/persons/" + url.PathEscape(personID)
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response
+// into out when out is not nil. Error responses are mapped onto the sentinel
+// errors.
+func (h *HTTPStore) do(ctx context.Context, method, url string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		content, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(content)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if h.token != "" {
+		req.Header.Set("Authorization", "Bearer "+h.token)
+	}
+	req.Header.Set(ActorHeader, actorFromContext(ctx))
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var apiErr apiErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+			return fmt.Errorf("persondb server returned %s", resp.Status)
+		}
+		if sentinel, ok := apiErrorCodes[apiErr.Error.Code]; ok {
+			// The server message starts with the sentinel text, keep only the
+			// details after it.
+			return fmt.Errorf("%w%s", sentinel, strings.TrimPrefix(apiErr.Error.Message, sentinel.Error()))
+		}
+		return fmt.Errorf("persondb server returned %s: %s", resp.Status, apiErr.Error.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (h *HTTPStore) CreatePerson(ctx context.Context, person Person) (*Person, error) {
+	var created Person
+	err := h.do(ctx, http.MethodPost, h.personURL(""), person, &created)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (h *HTTPStore) ReadPerson(ctx context.Context, personID string) (*Person, error) {
+	var person Person
+	err := h.do(ctx, http.MethodGet, h.personURL(personID), nil, &person)
+	if err != nil {
+		return nil, err
+	}
+	return &person, nil
+}
+
+func (h *HTTPStore) UpdatePerson(ctx context.Context, person Person) (*Person, error) {
+	var updated Person
+	err := h.do(ctx, http.MethodPut, h.personURL(person.PersonID), person, &updated)
+	if err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (h *HTTPStore) DeletePerson(ctx context.Context, personID string) error {
+	return h.do(ctx, http.MethodDelete, h.personURL(personID), nil, nil)
+}
+
+func (h *HTTPStore) CheckPersonExists(ctx context.Context, personID string) (bool, error) {
+	_, err := h.ReadPerson(ctx, personID)
+	if errors.Is(err, ErrPersonNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (h *HTTPStore) ListAuditEntries(ctx context.Context, personID string) ([]AuditEntry, error) {
+	var response struct {
+		Entries []AuditEntry `json:"entries"`
+	}
+	err := h.do(ctx, http.MethodGet, h.personURL(personID)+"/audit", nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Entries, nil
+}
+
+func (h *HTTPStore) ListPersons(ctx context.Context, filter ListFilter) ([]Person, error) {
+	query := url.Values{}
+	if filter.LastNamePrefix != "" {
+		query.Set("last_name_prefix", filter.LastNamePrefix)
+	}
+	if filter.FirstName != "" {
+		query.Set("first_name", filter.FirstName)
+	}
+	for _, personID := range filter.PersonIDs {
+		query.Add("person_id", personID)
+	}
+	for key, value := range filter.Tags {
+		query.Add("tag", key+"="+value)
+	}
+	if filter.OrderBy != "" {
+		query.Set("order_by", filter.OrderBy)
+	}
+	if filter.Descending {
+		query.Set("descending", "true")
+	}
+	if filter.Limit != 0 {
+		query.Set("limit", strconv.Itoa(filter.Limit))
+	}
+	if filter.Offset != 0 {
+		query.Set("offset", strconv.Itoa(filter.Offset))
+	}
+
+	listURL := h.personURL("")
+	if len(query) > 0 {
+		listURL += "?" + query.Encode()
+	}
+	var response struct {
+		Persons []Person `json:"persons"`
+	}
+	err := h.do(ctx, http.MethodGet, listURL, nil, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Persons, nil
+}
+
+func (h *HTTPStore) PurgeDeletedPersons(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	request := struct {
+		DeletedBefore time.Time `json:"deleted_before"`
+	}{
+		DeletedBefore: deletedBefore,
+	}
+	var response struct {
+		Purged int64 `json:"purged"`
+	}
+	err := h.do(ctx, http.MethodPost, h.endpoint+"/v1/purge", request, &response)
+	if err != nil {
+		return 0, err
+	}
+	return response.Purged, nil
+}
+
+// Close releases idle connections to the server.
+func (h *HTTPStore) Close() error {
+	h.httpClient.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// JSONFileStore is a PersonStore that keeps persons in a JSON document on
+// disk. The file is read on every call so changes made outside of Terraform
+// are detected, and rewritten atomically after every change.
+type JSONFileStore struct {
+	mu       sync.Mutex
+	filename string
+	options  Options
+}
+
+func NewJSONFileStore(filename string, options Options) (*JSONFileStore, error) {
+	s := &JSONFileStore{
+		filename: filename,
+		options:  options,
+	}
+	// Fail early on an unreadable or malformed file.
+	if _, err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// load reads the persons from the file, a missing file is an empty table.
+func (s *JSONFileStore) load() (*personTable, error) {
+	table := newPersonTable()
+	content, err := os.ReadFile(s.filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return table, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, table); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDatabaseCorrupt, err)
+	}
+	if table.Persons == nil {
+		table.Persons = map[string]Person{}
+	}
+	return table, nil
+}
+
+// save writes the persons to a temporary file which then replaces the
+// original, so readers never see a partially written file.
+func (s *JSONFileStore) save(table *personTable) error {
+	content, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.filename), filepath.Base(s.filename)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.filename)
+}
+
+// view runs fn against the current file contents.
+func (s *JSONFileStore) view(ctx context.Context, fn func(*personTable) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	table, err := s.load()
+	if err != nil {
+		return err
+	}
+	return fn(table)
+}
+
+// change runs fn against the current file contents and saves the result
+// when fn succeeds.
+func (s *JSONFileStore) change(ctx context.Context, fn func(*personTable) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	table, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := fn(table); err != nil {
+		return err
+	}
+	return s.save(table)
+}
+
+func (s *JSONFileStore) CreatePerson(ctx context.Context, person Person) (*Person, error) {
+	var created *Person
+	err := s.change(ctx, func(t *personTable) error {
+		var err error
+		created, err = t.create(ctx, person)
+		return err
+	})
+	return created, err
+}
+
+func (s *JSONFileStore) ReadPerson(ctx context.Context, personID string) (*Person, error) {
+	var person *Person
+	err := s.view(ctx, func(t *personTable) error {
+		var err error
+		person, err = t.read(personID)
+		return err
+	})
+	return person, err
+}
+
+func (s *JSONFileStore) UpdatePerson(ctx context.Context, person Person) (*Person, error) {
+	var updated *Person
+	err := s.change(ctx, func(t *personTable) error {
+		var err error
+		updated, err = t.update(ctx, person)
+		return err
+	})
+	return updated, err
+}
+
+func (s *JSONFileStore) DeletePerson(ctx context.Context, personID string) error {
+	return s.change(ctx, func(t *personTable) error {
+		return t.delete(ctx, personID, s.options.SoftDelete)
+	})
+}
+
+func (s *JSONFileStore) CheckPersonExists(ctx context.Context, personID string) (bool, error) {
+	var exists bool
+	err := s.view(ctx, func(t *personTable) error {
+		exists = t.exists(personID)
+		return nil
+	})
+	return exists, err
+}
+
+func (s *JSONFileStore) ListAuditEntries(ctx context.Context, personID string) ([]AuditEntry, error) {
+	var entries []AuditEntry
+	err := s.view(ctx, func(t *personTable) error {
+		entries = t.auditEntries(personID)
+		return nil
+	})
+	return entries, err
+}
+
+func (s *JSONFileStore) ListPersons(ctx context.Context, filter ListFilter) ([]Person, error) {
+	var persons []Person
+	err := s.view(ctx, func(t *personTable) error {
+		var err error
+		persons, err = t.list(filter)
+		return err
+	})
+	return persons, err
+}
+
+func (s *JSONFileStore) PurgeDeletedPersons(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	var purged int64
+	err := s.change(ctx, func(t *personTable) error {
+		purged = t.purge(ctx, deletedBefore)
+		return nil
+	})
+	return purged, err
+}
+
+// Close is a no-op, the file is only held open during a call.
+func (s *JSONFileStore) Close() error {
+	return nil
+}
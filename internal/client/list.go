@@ -0,0 +1,200 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Orderings supported by ListPersons.
+const (
+	OrderByPersonID  = "person_id"
+	OrderByLastName  = "last_name"
+	OrderByFirstName = "first_name"
+)
+
+// OrderByValues lists the orderings supported by ListPersons.
+var OrderByValues = []string{OrderByPersonID, OrderByLastName, OrderByFirstName}
+
+// ListFilter selects the persons returned by ListPersons. Empty fields do not
+// filter.
+type ListFilter struct {
+	// LastNamePrefix matches persons whose last name starts with the prefix,
+	// case-sensitively.
+	LastNamePrefix string `json:"last_name_prefix,omitempty"`
+
+	// FirstName matches persons with exactly this first name.
+	FirstName string `json:"first_name,omitempty"`
+
+	// PersonIDs matches persons with one of the person IDs.
+	PersonIDs []string `json:"person_ids,omitempty"`
+
+	// Tags matches persons having all of these tags with the same values.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// OrderBy is one of OrderByValues, defaults to OrderByPersonID. Ties are
+	// ordered by person_id.
+	OrderBy string `json:"order_by,omitempty"`
+
+	// Descending reverses the order.
+	Descending bool `json:"descending,omitempty"`
+
+	// Limit is the maximum number of persons returned, 0 means no limit.
+	Limit int `json:"limit,omitempty"`
+
+	// Offset skips the first persons of the ordered result.
+	Offset int `json:"offset,omitempty"`
+}
+
+// validate checks the ordering and paging of the filter.
+func (f ListFilter) validate() error {
+	if f.OrderBy != "" && !slices.Contains(OrderByValues, f.OrderBy) {
+		return fmt.Errorf("%w: unsupported order_by %q, expected one of %s", ErrInvalidFilter, f.OrderBy, strings.Join(OrderByValues, ", "))
+	}
+	if f.Limit < 0 || f.Offset < 0 {
+		return fmt.Errorf("%w: limit and offset must not be negative", ErrInvalidFilter)
+	}
+	if _, ok := f.Tags[""]; ok {
+		return fmt.Errorf("%w: tag keys must not be empty", ErrInvalidFilter)
+	}
+	return nil
+}
+
+// orderBy returns the ordering column, it must only be called on a
+// validated filter.
+func (f ListFilter) orderBy() string {
+	if f.OrderBy == "" {
+		return OrderByPersonID
+	}
+	return f.OrderBy
+}
+
+// matches reports whether person passes the filters, ordering and paging
+// are not taken into account.
+func (f ListFilter) matches(person Person) bool {
+	if !strings.HasPrefix(person.LastName, f.LastNamePrefix) {
+		return false
+	}
+	if f.FirstName != "" && person.FirstName != f.FirstName {
+		return false
+	}
+	if len(f.PersonIDs) > 0 && !slices.Contains(f.PersonIDs, person.PersonID) {
+		return false
+	}
+	for key, value := range f.Tags {
+		if tag, ok := person.Tags[key]; !ok || tag != value {
+			return false
+		}
+	}
+	return true
+}
+
+// filterPersons applies the filter, ordering and paging to persons in memory.
+func filterPersons(persons []Person, filter ListFilter) ([]Person, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+	var result []Person
+	for _, person := range persons {
+		if person.DeletedAt == nil && filter.matches(person) {
+			result = append(result, person)
+		}
+	}
+
+	sortKey := func(person Person) string {
+		switch filter.orderBy() {
+		case OrderByLastName:
+			return person.LastName
+		case OrderByFirstName:
+			return person.FirstName
+		default:
+			return person.PersonID
+		}
+	}
+	slices.SortFunc(result, func(a, b Person) int {
+		order := strings.Compare(sortKey(a), sortKey(b))
+		if order == 0 {
+			order = strings.Compare(a.PersonID, b.PersonID)
+		}
+		if filter.Descending {
+			return -order
+		}
+		return order
+	})
+
+	if filter.Offset >= len(result) {
+		return nil, nil
+	}
+	result = result[filter.Offset:]
+	if filter.Limit > 0 && filter.Limit < len(result) {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
+
+func (c *Client) ListPersons(ctx context.Context, filter ListFilter) ([]Person, error) {
+	if err := filter.validate(); err != nil {
+		return nil, err
+	}
+
+	query := "SELECT person_id, last_name, first_name, name_prefix, middle_name, name_suffix, preferred_name, email, birth_date, version FROM persons WHERE deleted_at IS NULL"
+	var args []any
+	if filter.LastNamePrefix != "" {
+		query += " AND substr(last_name, 1, length(?)) = ?"
+		args = append(args, filter.LastNamePrefix, filter.LastNamePrefix)
+	}
+	if filter.FirstName != "" {
+		query += " AND first_name = ?"
+		args = append(args, filter.FirstName)
+	}
+	if len(filter.PersonIDs) > 0 {
+		query += " AND person_id IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(filter.PersonIDs)), ", ") + ")"
+		for _, personID := range filter.PersonIDs {
+			args = append(args, personID)
+		}
+	}
+	for key, value := range filter.Tags {
+		query += " AND EXISTS (SELECT 1 FROM person_tags WHERE person_tags.person_id = persons.person_id AND key = ? AND value = ?)"
+		args = append(args, key, value)
+	}
+	direction := "ASC"
+	if filter.Descending {
+		direction = "DESC"
+	}
+	// The column comes from the validated filter, never from user input.
+	query += fmt.Sprintf(" ORDER BY %s %s, person_id %s", filter.orderBy(), direction, direction)
+	if filter.Limit > 0 || filter.Offset > 0 {
+		limit := filter.Limit
+		if limit == 0 {
+			limit = -1
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, filter.Offset)
+	}
+
+	var persons []Person
+	err := c.withRetry(ctx, "list persons", func() error {
+		persons = nil
+		rows, err := c.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			return classifyError(err)
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var person Person
+			if err := rows.Scan(&person.PersonID, &person.LastName, &person.FirstName, &person.NamePrefix, &person.MiddleName, &person.NameSuffix, &person.PreferredName, &person.Email, &person.BirthDate, &person.Version); err != nil {
+				return classifyError(err)
+			}
+			persons = append(persons, person)
+		}
+		if err := rows.Err(); err != nil {
+			return classifyError(err)
+		}
+		return loadPersonDetails(ctx, c.db, persons)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return persons, nil
+}
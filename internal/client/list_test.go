@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestListPersons(t *testing.T) {
+	ctx := context.Background()
+
+	newStores := map[string]func(t *testing.T) PersonStore{
+		"sqlite": func(t *testing.T) PersonStore {
+			return newTestClient(t, filepath.Join(t.TempDir(), "persons.db"))
+		},
+		"memory": func(t *testing.T) PersonStore {
+			return NewMemoryStore(Options{})
+		},
+		"json file": func(t *testing.T) PersonStore {
+			store, err := NewJSONFileStore(filepath.Join(t.TempDir(), "persons.json"), Options{})
+			if err != nil {
+				t.Fatalf("NewJSONFileStore: %v", err)
+			}
+			return store
+		},
+	}
+
+	persons := []Person{
+		{PersonID: "1", LastName: "Peeters", FirstName: "Jan", Tags: map[string]string{"team": "platform"}},
+		{PersonID: "2", LastName: "Janssens", FirstName: "Marie", Tags: map[string]string{"team": "platform", "owner": "terraform"}},
+		{PersonID: "3", LastName: "Peeters", FirstName: "An"},
+		{PersonID: "4", LastName: "Maes", FirstName: "Jan"},
+	}
+
+	testCases := map[string]struct {
+		filter  ListFilter
+		want    []string
+		wantErr error
+	}{
+		"all":                      {want: []string{"1", "2", "3", "4"}},
+		"last name prefix":         {filter: ListFilter{LastNamePrefix: "Pee"}, want: []string{"1", "3"}},
+		"last name prefix case":    {filter: ListFilter{LastNamePrefix: "pee"}},
+		"first name":               {filter: ListFilter{FirstName: "Jan"}, want: []string{"1", "4"}},
+		"person IDs":               {filter: ListFilter{PersonIDs: []string{"4", "2", "9"}}, want: []string{"2", "4"}},
+		"tags":                     {filter: ListFilter{Tags: map[string]string{"team": "platform", "owner": "terraform"}}, want: []string{"2"}},
+		"order by last name":       {filter: ListFilter{OrderBy: OrderByLastName}, want: []string{"2", "4", "1", "3"}},
+		"order by first name":      {filter: ListFilter{OrderBy: OrderByFirstName}, want: []string{"3", "1", "4", "2"}},
+		"descending":               {filter: ListFilter{OrderBy: OrderByLastName, Descending: true}, want: []string{"3", "1", "4", "2"}},
+		"limit":                    {filter: ListFilter{Limit: 2}, want: []string{"1", "2"}},
+		"offset":                   {filter: ListFilter{Offset: 3}, want: []string{"4"}},
+		"limit and offset":         {filter: ListFilter{OrderBy: OrderByFirstName, Limit: 2, Offset: 1}, want: []string{"1", "4"}},
+		"offset past the end":      {filter: ListFilter{Offset: 10}},
+		"unsupported order by":     {filter: ListFilter{OrderBy: "email"}, wantErr: ErrInvalidFilter},
+		"negative limit":           {filter: ListFilter{Limit: -1}, wantErr: ErrInvalidFilter},
+		"negative offset":          {filter: ListFilter{Offset: -1}, wantErr: ErrInvalidFilter},
+		"empty tag key":            {filter: ListFilter{Tags: map[string]string{"": "platform"}}, wantErr: ErrInvalidFilter},
+		"filter, order and paging": {filter: ListFilter{FirstName: "Jan", OrderBy: OrderByLastName, Descending: true, Limit: 1}, want: []string{"1"}},
+	}
+
+	for storeName, newStore := range newStores {
+		t.Run(storeName, func(t *testing.T) {
+			store := newStore(t)
+			for _, person := range persons {
+				if _, err := store.CreatePerson(ctx, person); err != nil {
+					t.Fatalf("CreatePerson: %v", err)
+				}
+			}
+
+			for name, testCase := range testCases {
+				t.Run(name, func(t *testing.T) {
+					got, err := store.ListPersons(ctx, testCase.filter)
+					if !errors.Is(err, testCase.wantErr) {
+						t.Fatalf("got error %v, want %v", err, testCase.wantErr)
+					}
+					var gotIDs []string
+					for _, person := range got {
+						gotIDs = append(gotIDs, person.PersonID)
+					}
+					if !slices.Equal(gotIDs, testCase.want) {
+						t.Errorf("got %v, want %v", gotIDs, testCase.want)
+					}
+				})
+			}
+		})
+	}
+}
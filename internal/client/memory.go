@@ -0,0 +1,282 @@
+package client
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// personTable holds the person records of the in-memory and JSON file
+// backends. It implements the storage rules once so both backends behave the
+// same; callers are responsible for locking.
+type personTable struct {
+	Persons map[string]Person `json:"persons"`
+	Audit   []AuditEntry      `json:"audit"`
+}
+
+func newPersonTable() *personTable {
+	return &personTable{
+		Persons: map[string]Person{},
+	}
+}
+
+// clonePerson returns a deep copy of the person, so records handed to or
+// returned by the table never share their collections with the caller.
+func clonePerson(person Person) Person {
+	person.PhoneNumbers = slices.Clone(person.PhoneNumbers)
+	person.Tags = maps.Clone(person.Tags)
+	person.Addresses = slices.Clone(person.Addresses)
+	if person.DeletedAt != nil {
+		deletedAt := *person.DeletedAt
+		person.DeletedAt = &deletedAt
+	}
+	return person
+}
+
+// clonePersonPointer returns a deep copy of the person, or nil.
+func clonePersonPointer(person *Person) *Person {
+	if person == nil {
+		return nil
+	}
+	clone := clonePerson(*person)
+	return &clone
+}
+
+// audit appends an entry to the audit log, with copies of the values.
+func (t *personTable) audit(entry AuditEntry) {
+	entry.ID = int64(len(t.Audit)) + 1
+	entry.OldValues = clonePersonPointer(entry.OldValues)
+	entry.NewValues = clonePersonPointer(entry.NewValues)
+	t.Audit = append(t.Audit, entry)
+}
+
+// live returns the person unless it is missing or tombstoned.
+func (t *personTable) live(personID string) (Person, bool) {
+	person, ok := t.Persons[personID]
+	if !ok || person.DeletedAt != nil {
+		return Person{}, false
+	}
+	return person, true
+}
+
+// emailTaken reports whether another live person uses the email address of
+// the person, compared case-insensitively.
+func (t *personTable) emailTaken(person Person) bool {
+	if person.Email == "" {
+		return false
+	}
+	for personID, other := range t.Persons {
+		if personID != person.PersonID && other.DeletedAt == nil && strings.EqualFold(other.Email, person.Email) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *personTable) create(ctx context.Context, person Person) (*Person, error) {
+	person = normalizePerson(person)
+	person.DeletedAt = nil
+	if t.emailTaken(person) {
+		return nil, ErrEmailAlreadyExists
+	}
+	tombstone, ok := t.Persons[person.PersonID]
+	switch {
+	case !ok:
+		person.Version = 1
+		t.Persons[person.PersonID] = clonePerson(person)
+		t.audit(newAuditEntry(ctx, AuditOperationCreate, nil, &person))
+	case tombstone.DeletedAt == nil:
+		return nil, ErrPersonAlreadyExists
+	default:
+		// Restore the tombstoned person with the new values
+		person.Version = tombstone.Version + 1
+		t.Persons[person.PersonID] = clonePerson(person)
+		t.audit(newAuditEntry(ctx, AuditOperationRestore, nil, &person))
+	}
+	return &person, nil
+}
+
+func (t *personTable) read(personID string) (*Person, error) {
+	person, ok := t.live(personID)
+	if !ok {
+		return nil, ErrPersonNotFound
+	}
+	person = clonePerson(person)
+	return &person, nil
+}
+
+func (t *personTable) update(ctx context.Context, person Person) (*Person, error) {
+	current, ok := t.live(person.PersonID)
+	if !ok {
+		return nil, ErrPersonNotFound
+	}
+	if err := checkVersion(person, &current); err != nil {
+		return nil, err
+	}
+	person = normalizePerson(person)
+	if t.emailTaken(person) {
+		return nil, ErrEmailAlreadyExists
+	}
+	person.Version = current.Version + 1
+	person.DeletedAt = nil
+	t.Persons[person.PersonID] = clonePerson(person)
+	t.audit(newAuditEntry(ctx, AuditOperationUpdate, &current, &person))
+	return &person, nil
+}
+
+func (t *personTable) delete(ctx context.Context, personID string, softDelete bool) error {
+	current, ok := t.live(personID)
+	if !ok {
+		return ErrPersonNotFound
+	}
+	if softDelete {
+		tombstone := current
+		deletedAt := time.Now().UTC()
+		tombstone.DeletedAt = &deletedAt
+		tombstone.Version++
+		t.Persons[personID] = tombstone
+	} else {
+		delete(t.Persons, personID)
+	}
+	t.audit(newAuditEntry(ctx, AuditOperationDelete, &current, nil))
+	return nil
+}
+
+func (t *personTable) exists(personID string) bool {
+	_, ok := t.live(personID)
+	return ok
+}
+
+func (t *personTable) purge(ctx context.Context, deletedBefore time.Time) int64 {
+	var personIDs []string
+	for personID, person := range t.Persons {
+		if person.DeletedAt != nil && person.DeletedAt.Before(deletedBefore) {
+			personIDs = append(personIDs, personID)
+		}
+	}
+	// Purge in a stable order, so the audit log does not depend on the map
+	sort.Strings(personIDs)
+	for _, personID := range personIDs {
+		tombstone := t.Persons[personID]
+		delete(t.Persons, personID)
+		t.audit(newAuditEntry(ctx, AuditOperationPurge, &tombstone, nil))
+	}
+	return int64(len(personIDs))
+}
+
+func (t *personTable) list(filter ListFilter) ([]Person, error) {
+	persons := make([]Person, 0, len(t.Persons))
+	for _, person := range t.Persons {
+		persons = append(persons, clonePerson(person))
+	}
+	return filterPersons(persons, filter)
+}
+
+func (t *personTable) auditEntries(personID string) []AuditEntry {
+	var entries []AuditEntry
+	for _, entry := range t.Audit {
+		if entry.PersonID == personID {
+			entry.OldValues = clonePersonPointer(entry.OldValues)
+			entry.NewValues = clonePersonPointer(entry.NewValues)
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// MemoryStore is a PersonStore that keeps persons in memory only. Its data is
+// lost when the provider process exits, which makes it suited for plans in CI
+// without a database file.
+type MemoryStore struct {
+	mu      sync.Mutex
+	table   *personTable
+	options Options
+}
+
+func NewMemoryStore(options Options) *MemoryStore {
+	return &MemoryStore{
+		table:   newPersonTable(),
+		options: options,
+	}
+}
+
+func (m *MemoryStore) CreatePerson(ctx context.Context, person Person) (*Person, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.table.create(ctx, person)
+}
+
+func (m *MemoryStore) ReadPerson(ctx context.Context, personID string) (*Person, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.table.read(personID)
+}
+
+func (m *MemoryStore) UpdatePerson(ctx context.Context, person Person) (*Person, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.table.update(ctx, person)
+}
+
+func (m *MemoryStore) DeletePerson(ctx context.Context, personID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.table.delete(ctx, personID, m.options.SoftDelete)
+}
+
+func (m *MemoryStore) CheckPersonExists(ctx context.Context, personID string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.table.exists(personID), nil
+}
+
+func (m *MemoryStore) ListAuditEntries(ctx context.Context, personID string) ([]AuditEntry, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.table.auditEntries(personID), nil
+}
+
+func (m *MemoryStore) ListPersons(ctx context.Context, filter ListFilter) ([]Person, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.table.list(filter)
+}
+
+func (m *MemoryStore) PurgeDeletedPersons(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.table.purge(ctx, deletedBefore), nil
+}
+
+// Close is a no-op, the data is released with the store.
+func (m *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemoryStoreDoesNotShareCollections(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore(Options{})
+
+	person := Person{
+		PersonID:     "1",
+		LastName:     "Peeters",
+		PhoneNumbers: []string{"+32 470 12 34 56"},
+		Tags:         map[string]string{"team": "platform"},
+		Addresses:    []Address{{Type: "home", City: "Antwerpen", Country: "BE"}},
+	}
+	created, err := store.CreatePerson(ctx, person)
+	if err != nil {
+		t.Fatalf("CreatePerson: %v", err)
+	}
+	mutate := func(person *Person) {
+		person.PhoneNumbers[0] = "+32 3 000 00 00"
+		person.Tags["team"] = "hr"
+		person.Addresses[0].City = "Gent"
+	}
+
+	// Change the argument and the results of every call
+	mutate(&person)
+	mutate(created)
+	read, err := store.ReadPerson(ctx, "1")
+	if err != nil {
+		t.Fatalf("ReadPerson: %v", err)
+	}
+	mutate(read)
+	persons, err := store.ListPersons(ctx, ListFilter{})
+	if err != nil {
+		t.Fatalf("ListPersons: %v", err)
+	}
+	mutate(&persons[0])
+	entries, err := store.ListAuditEntries(ctx, "1")
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	mutate(entries[0].NewValues)
+
+	stored, err := store.ReadPerson(ctx, "1")
+	if err != nil {
+		t.Fatalf("ReadPerson: %v", err)
+	}
+	if stored.PhoneNumbers[0] != "+32 470 12 34 56" || stored.Tags["team"] != "platform" || stored.Addresses[0].City != "Antwerpen" {
+		t.Errorf("stored person changed through a returned value: got %+v", stored)
+	}
+	entries, err = store.ListAuditEntries(ctx, "1")
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	if audited := entries[0].NewValues; audited.Tags["team"] != "platform" || audited.Addresses[0].City != "Antwerpen" {
+		t.Errorf("audit entry changed through a returned value: got %+v", audited)
+	}
+}
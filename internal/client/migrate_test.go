@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// appliedMigrations returns the number of rows in schema_migrations and the
+// highest applied version.
+func appliedMigrations(t *testing.T, c *Client) (int, int) {
+	t.Helper()
+	var count, version int
+	err := c.db.QueryRow("SELECT COUNT(*), COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&count, &version)
+	if err != nil {
+		t.Fatalf("reading schema_migrations: %v", err)
+	}
+	return count, version
+}
+
+func TestMigrate(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	latest := migrations[len(migrations)-1].version
+
+	t.Run("new database", func(t *testing.T) {
+		c := newTestClient(t, filepath.Join(t.TempDir(), "persons.db"))
+		count, version := appliedMigrations(t, c)
+		if count != len(migrations) || version != latest {
+			t.Errorf("got %d migrations up to version %d, want %d up to version %d", count, version, len(migrations), latest)
+		}
+	})
+
+	t.Run("reopened database", func(t *testing.T) {
+		databaseFilename := filepath.Join(t.TempDir(), "persons.db")
+		newTestClient(t, databaseFilename).Close()
+		c := newTestClient(t, databaseFilename)
+		count, version := appliedMigrations(t, c)
+		if count != len(migrations) || version != latest {
+			t.Errorf("got %d migrations up to version %d, want every migration applied once", count, version)
+		}
+	})
+
+	t.Run("database of the first provider version", func(t *testing.T) {
+		databaseFilename := filepath.Join(t.TempDir(), "persons.db")
+		db, err := sql.Open("sqlite3", databaseFilename)
+		if err != nil {
+			t.Fatalf("sql.Open: %v", err)
+		}
+		_, err = db.Exec("CREATE TABLE persons (person_id TEXT NOT NULL PRIMARY KEY, last_name TEXT NOT NULL, first_name TEXT); " +
+			"INSERT INTO persons VALUES ('1', 'Peeters', 'Jan')")
+		db.Close()
+		if err != nil {
+			t.Fatalf("creating the unversioned database: %v", err)
+		}
+
+		c := newTestClient(t, databaseFilename)
+		person, err := c.ReadPerson(context.Background(), "1")
+		if err != nil {
+			t.Fatalf("ReadPerson: %v", err)
+		}
+		if person.LastName != "Peeters" || person.FirstName != "Jan" || person.Version != 1 {
+			t.Errorf("ReadPerson: got %+v", person)
+		}
+	})
+
+	t.Run("newer database", func(t *testing.T) {
+		databaseFilename := filepath.Join(t.TempDir(), "persons.db")
+		c := newTestClient(t, databaseFilename)
+		if _, err := c.db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, 'future', '')", latest+1); err != nil {
+			t.Fatalf("inserting a future migration: %v", err)
+		}
+		c.Close()
+
+		_, err := NewClient(context.Background(), DefaultConfig(databaseFilename))
+		if !errors.Is(err, ErrSchemaTooNew) {
+			t.Errorf("NewClient: got error %v, want %v", err, ErrSchemaTooNew)
+		}
+	})
+}
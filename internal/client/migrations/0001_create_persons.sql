@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS persons (
+	person_id TEXT NOT NULL PRIMARY KEY,
+	last_name TEXT NOT NULL,
+	first_name TEXT
+);
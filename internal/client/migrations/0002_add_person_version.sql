@@ -0,0 +1 @@
+ALTER TABLE persons ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
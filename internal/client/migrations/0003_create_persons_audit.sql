@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS persons_audit (
+	audit_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	person_id TEXT NOT NULL,
+	changed_at TEXT NOT NULL,
+	operation TEXT NOT NULL,
+	actor TEXT NOT NULL,
+	old_values TEXT,
+	new_values TEXT
+);
+
+CREATE INDEX IF NOT EXISTS persons_audit_person_id ON persons_audit (person_id);
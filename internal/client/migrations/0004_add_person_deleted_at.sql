@@ -0,0 +1,3 @@
+ALTER TABLE persons ADD COLUMN deleted_at TEXT;
+
+CREATE INDEX IF NOT EXISTS persons_deleted_at ON persons (deleted_at);
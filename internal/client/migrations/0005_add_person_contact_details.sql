@@ -0,0 +1,10 @@
+ALTER TABLE persons ADD COLUMN email TEXT NOT NULL DEFAULT '';
+ALTER TABLE persons ADD COLUMN birth_date TEXT NOT NULL DEFAULT '';
+
+CREATE UNIQUE INDEX IF NOT EXISTS persons_email ON persons (lower(email)) WHERE email <> '' AND deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS person_phone_numbers (
+	person_id TEXT NOT NULL REFERENCES persons (person_id) ON DELETE CASCADE,
+	phone_number TEXT NOT NULL,
+	PRIMARY KEY (person_id, phone_number)
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS person_tags (
+	person_id TEXT NOT NULL REFERENCES persons (person_id) ON DELETE CASCADE,
+	key TEXT NOT NULL,
+	value TEXT NOT NULL,
+	PRIMARY KEY (person_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS person_tags_key_value ON person_tags (key, value);
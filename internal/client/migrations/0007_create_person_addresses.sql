@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS person_addresses (
+	person_id TEXT NOT NULL REFERENCES persons (person_id) ON DELETE CASCADE,
+	position INTEGER NOT NULL,
+	type TEXT NOT NULL,
+	street TEXT NOT NULL,
+	city TEXT NOT NULL,
+	postal_code TEXT NOT NULL DEFAULT '',
+	country TEXT NOT NULL,
+	PRIMARY KEY (person_id, position)
+);
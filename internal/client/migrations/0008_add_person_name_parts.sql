@@ -0,0 +1,4 @@
+ALTER TABLE persons ADD COLUMN name_prefix TEXT NOT NULL DEFAULT '';
+ALTER TABLE persons ADD COLUMN middle_name TEXT NOT NULL DEFAULT '';
+ALTER TABLE persons ADD COLUMN name_suffix TEXT NOT NULL DEFAULT '';
+ALTER TABLE persons ADD COLUMN preferred_name TEXT NOT NULL DEFAULT '';
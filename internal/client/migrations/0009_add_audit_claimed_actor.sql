@@ -0,0 +1 @@
+ALTER TABLE persons_audit ADD COLUMN claimed_actor TEXT NOT NULL DEFAULT '';
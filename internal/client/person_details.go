@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// normalizePerson returns the person as it is stored: phone numbers are a
+// sorted set, empty collections are nil, and none are shared with the caller.
+func normalizePerson(person Person) Person {
+	person.PhoneNumbers = slices.Compact(slices.Sorted(slices.Values(person.PhoneNumbers)))
+	if len(person.PhoneNumbers) == 0 {
+		person.PhoneNumbers = nil
+	}
+	person.Tags = maps.Clone(person.Tags)
+	if len(person.Tags) == 0 {
+		person.Tags = nil
+	}
+	person.Addresses = slices.Clone(person.Addresses)
+	if len(person.Addresses) == 0 {
+		person.Addresses = nil
+	}
+	return person
+}
+
+// checkEmailAvailable returns ErrEmailAlreadyExists when another live person
+// uses the email address of the person, compared case-insensitively.
+func checkEmailAvailable(ctx context.Context, tx *sql.Tx, person Person) error {
+	if person.Email == "" {
+		return nil
+	}
+	var taken bool
+	err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM persons WHERE lower(email) = lower(?) AND person_id <> ? AND deleted_at IS NULL)",
+		person.Email, person.PersonID).Scan(&taken)
+	if err != nil {
+		return classifyError(err)
+	}
+	if taken {
+		return ErrEmailAlreadyExists
+	}
+	return nil
+}
+
+// personDetailsBatchSize is the number of persons whose details are loaded
+// per query, well below the SQLite limit on the number of bind variables.
+const personDetailsBatchSize = 500
+
+// loadPersonDetails fills in the details of the persons that are stored in
+// their own tables.
+func loadPersonDetails(ctx context.Context, q queryer, persons []Person) error {
+	for batch := range slices.Chunk(persons, personDetailsBatchSize) {
+		if err := loadPersonDetailsBatch(ctx, q, batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadPersonDetailsBatch fills in the details of at most
+// personDetailsBatchSize persons.
+func loadPersonDetailsBatch(ctx context.Context, q queryer, persons []Person) error {
+	index := make(map[string]int, len(persons))
+	args := make([]any, 0, len(persons))
+	for i, person := range persons {
+		index[person.PersonID] = i
+		args = append(args, person.PersonID)
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(persons)), ", ")
+
+	rows, err := q.QueryContext(ctx, "SELECT person_id, phone_number FROM person_phone_numbers WHERE person_id IN ("+placeholders+") ORDER BY person_id, phone_number", args...)
+	if err != nil {
+		return classifyError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var personID, phoneNumber string
+		if err := rows.Scan(&personID, &phoneNumber); err != nil {
+			return classifyError(err)
+		}
+		i := index[personID]
+		persons[i].PhoneNumbers = append(persons[i].PhoneNumbers, phoneNumber)
+	}
+	if err := rows.Err(); err != nil {
+		return classifyError(err)
+	}
+
+	rows, err = q.QueryContext(ctx, "SELECT person_id, key, value FROM person_tags WHERE person_id IN ("+placeholders+")", args...)
+	if err != nil {
+		return classifyError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var personID, key, value string
+		if err := rows.Scan(&personID, &key, &value); err != nil {
+			return classifyError(err)
+		}
+		i := index[personID]
+		if persons[i].Tags == nil {
+			persons[i].Tags = map[string]string{}
+		}
+		persons[i].Tags[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return classifyError(err)
+	}
+
+	rows, err = q.QueryContext(ctx, "SELECT person_id, type, street, city, postal_code, country FROM person_addresses WHERE person_id IN ("+placeholders+") ORDER BY person_id, position", args...)
+	if err != nil {
+		return classifyError(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var personID string
+		var address Address
+		if err := rows.Scan(&personID, &address.Type, &address.Street, &address.City, &address.PostalCode, &address.Country); err != nil {
+			return classifyError(err)
+		}
+		i := index[personID]
+		persons[i].Addresses = append(persons[i].Addresses, address)
+	}
+	return classifyError(rows.Err())
+}
+
+// savePersonDetails replaces the details of the person that are stored in
+// their own tables.
+func savePersonDetails(ctx context.Context, tx *sql.Tx, person Person) error {
+	if err := deletePersonDetails(ctx, tx, person.PersonID); err != nil {
+		return err
+	}
+	for _, phoneNumber := range person.PhoneNumbers {
+		_, err := tx.ExecContext(ctx, "INSERT INTO person_phone_numbers (person_id, phone_number) VALUES (?, ?)", person.PersonID, phoneNumber)
+		if err != nil {
+			return classifyError(err)
+		}
+	}
+	for key, value := range person.Tags {
+		_, err := tx.ExecContext(ctx, "INSERT INTO person_tags (person_id, key, value) VALUES (?, ?, ?)", person.PersonID, key, value)
+		if err != nil {
+			return classifyError(err)
+		}
+	}
+	for position, address := range person.Addresses {
+		_, err := tx.ExecContext(ctx, "INSERT INTO person_addresses (person_id, position, type, street, city, postal_code, country) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			person.PersonID, position, address.Type, address.Street, address.City, address.PostalCode, address.Country)
+		if err != nil {
+			return classifyError(err)
+		}
+	}
+	return nil
+}
+
+// deletePersonDetails removes the details of the person that are stored in
+// their own tables. Foreign keys may be disabled, so they are not relied on.
+func deletePersonDetails(ctx context.Context, tx *sql.Tx, personID string) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM person_phone_numbers WHERE person_id = ?", personID)
+	if err != nil {
+		return classifyError(err)
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM person_tags WHERE person_id = ?", personID)
+	if err != nil {
+		return classifyError(err)
+	}
+	_, err = tx.ExecContext(ctx, "DELETE FROM person_addresses WHERE person_id = ?", personID)
+	return classifyError(err)
+}
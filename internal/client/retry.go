@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// initialRetryBackoff is the wait before the first retry, it doubles on every
+// following retry up to Config.RetryMaxWait.
+const initialRetryBackoff = 50 * time.Millisecond
+
+// withRetry runs op and retries it with bounded exponential backoff for as
+// long as it fails with ErrDatabaseLocked and retries are left. op must
+// return classified errors.
+func (c *Client) withRetry(ctx context.Context, operation string, op func() error) error {
+	backoff := initialRetryBackoff
+	for attempt := 1; ; attempt++ {
+		err := op()
+		if !errors.Is(err, ErrDatabaseLocked) || attempt > c.config.MaxRetries {
+			return err
+		}
+
+		// Wait between half and the full backoff, so parallel writers do not
+		// retry in lockstep.
+		wait := min(backoff, c.config.RetryMaxWait)
+		wait = wait/2 + rand.N(wait/2+1)
+		tflog.Warn(ctx, "Persons database is locked, retrying", map[string]any{
+			"operation":   operation,
+			"attempt":     attempt,
+			"max_retries": c.config.MaxRetries,
+			"wait":        wait.String(),
+			"error":       err.Error(),
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%w while retrying: %w", ctx.Err(), err)
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithRetry(t *testing.T) {
+	errOther := errors.New("other error")
+
+	testCases := map[string]struct {
+		errs         []error
+		wantErr      error
+		wantAttempts int
+	}{
+		"success": {
+			errs:         []error{nil},
+			wantAttempts: 1,
+		},
+		"locked then success": {
+			errs:         []error{ErrDatabaseLocked, ErrDatabaseLocked, nil},
+			wantAttempts: 3,
+		},
+		"locked until out of retries": {
+			errs:         []error{ErrDatabaseLocked, ErrDatabaseLocked, ErrDatabaseLocked, ErrDatabaseLocked},
+			wantErr:      ErrDatabaseLocked,
+			wantAttempts: 3,
+		},
+		"other error not retried": {
+			errs:         []error{errOther, nil},
+			wantErr:      errOther,
+			wantAttempts: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := &Client{config: Config{MaxRetries: 2, RetryMaxWait: time.Millisecond}}
+			attempts := 0
+			err := c.withRetry(context.Background(), "test", func() error {
+				err := testCase.errs[attempts]
+				attempts++
+				return err
+			})
+			if !errors.Is(err, testCase.wantErr) {
+				t.Errorf("got error %v, want %v", err, testCase.wantErr)
+			}
+			if attempts != testCase.wantAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, testCase.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestWithRetryCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	c := &Client{config: Config{MaxRetries: 5, RetryMaxWait: time.Hour}}
+	attempts := 0
+	err := c.withRetry(ctx, "test", func() error {
+		attempts++
+		return ErrDatabaseLocked
+	})
+	if !errors.Is(err, context.Canceled) || !errors.Is(err, ErrDatabaseLocked) {
+		t.Errorf("got error %v, want %v and %v", err, context.Canceled, ErrDatabaseLocked)
+	}
+	if attempts != 1 {
+		t.Errorf("got %d attempts, want 1", attempts)
+	}
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// Person is a person record in the database.
+type Person struct {
+	PersonID string `json:"person_id"`
+
+	// LastName is the family name and FirstName the given name of the
+	// person, the other name parts are optional.
+	LastName      string `json:"last_name"`
+	FirstName     string `json:"first_name"`
+	NamePrefix    string `json:"name_prefix,omitempty"`
+	MiddleName    string `json:"middle_name,omitempty"`
+	NameSuffix    string `json:"name_suffix,omitempty"`
+	PreferredName string `json:"preferred_name,omitempty"`
+
+	// Email is unique among the persons, compared case-insensitively.
+	Email string `json:"email,omitempty"`
+
+	// BirthDate is an RFC 3339 full-date such as "1990-12-31".
+	BirthDate string `json:"birth_date,omitempty"`
+
+	// PhoneNumbers is a set, stored sorted.
+	PhoneNumbers []string `json:"phone_numbers,omitempty"`
+
+	// Tags label the person with free-form key/value pairs.
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Addresses are kept in the order they were given.
+	Addresses []Address `json:"addresses,omitempty"`
+
+	// Version is incremented on every change of the person. It is used for
+	// optimistic concurrency control on updates.
+	Version int64 `json:"version"`
+
+	// DeletedAt is set on the tombstone of a soft deleted person. Tombstones
+	// are never returned by ReadPerson.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Address is a postal address of a person.
+type Address struct {
+	// Type is the kind of address, such as "home" or "work".
+	Type       string `json:"type"`
+	Street     string `json:"street"`
+	City       string `json:"city"`
+	PostalCode string `json:"postal_code,omitempty"`
+
+	// Country is an ISO 3166-1 alpha-2 country code such as "BE".
+	Country string `json:"country"`
+}
+
+// PersonStore is the storage backend behind the provider. Implementations
+// return the sentinel errors defined in this package, so callers can handle
+// not-found and conflicts the same way for every backend. Every change is
+// recorded in the audit log together with the actor set on the context, see
+// ContextWithActor.
+type PersonStore interface {
+	// CreatePerson inserts a new person and returns the stored record, it
+	// returns ErrPersonAlreadyExists when the person_id is already taken, or
+	// ErrEmailAlreadyExists when another person uses the email. A soft
+	// deleted person with the same person_id is restored.
+	CreatePerson(ctx context.Context, person Person) (*Person, error)
+
+	// ReadPerson returns the person, or ErrPersonNotFound. Soft deleted
+	// persons are not found.
+	ReadPerson(ctx context.Context, personID string) (*Person, error)
+
+	// UpdatePerson overwrites an existing person and returns the stored
+	// record, or returns ErrPersonNotFound. When person.Version is not zero
+	// the update only succeeds if it matches the stored version, otherwise
+	// ErrVersionConflict is returned. ErrEmailAlreadyExists is returned when
+	// another person uses the email.
+	UpdatePerson(ctx context.Context, person Person) (*Person, error)
+
+	// DeletePerson removes the person, or returns ErrPersonNotFound. With
+	// soft delete enabled the person is only marked deleted.
+	DeletePerson(ctx context.Context, personID string) error
+
+	// CheckPersonExists reports whether a person with the person_id exists.
+	// Soft deleted persons do not exist.
+	CheckPersonExists(ctx context.Context, personID string) (bool, error)
+
+	// ListPersons returns the persons matching the filter, soft deleted
+	// persons are never listed. It returns ErrInvalidFilter for an invalid
+	// ordering or paging.
+	ListPersons(ctx context.Context, filter ListFilter) ([]Person, error)
+
+	// PurgeDeletedPersons permanently removes the persons soft deleted before
+	// deletedBefore and returns how many were removed. Each removal is
+	// recorded as a purge in the audit log.
+	PurgeDeletedPersons(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	// ListAuditEntries returns the audit log of a person, oldest first. The
+	// log is kept after the person is deleted.
+	ListAuditEntries(ctx context.Context, personID string) ([]AuditEntry, error)
+
+	// Close releases the resources held by the backend.
+	Close() error
+}
+
+// Ensure the implementations satisfy the PersonStore interface.
+var (
+	_ PersonStore = &Client{}
+	_ PersonStore = &MemoryStore{}
+	_ PersonStore = &JSONFileStore{}
+	_ PersonStore = &HTTPStore{}
+)
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &FormatIDFunction{}
+
+// NewFormatIDFunction is a helper function to simplify the provider implementation.
+func NewFormatIDFunction() function.Function {
+	return &FormatIDFunction{}
+}
+
+// FormatIDFunction is the format_id function implementation.
+type FormatIDFunction struct{}
+
+// Metadata returns the function name.
+func (f *FormatIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "format_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *FormatIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Returns the persondb_person ID of a person ID.",
+		Description: "Returns the canonical persondb_person ID \"/person/<person_id>\" of a person ID.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "person_id",
+				Description: "Person ID in the database.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run formats the ID.
+func (f *FormatIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var personID string
+
+	resp.Error = req.Arguments.Get(ctx, &personID)
+	if resp.Error != nil {
+		return
+	}
+
+	// Parse the formatted ID, so only IDs that can be parsed again are returned
+	id := formatPersonID(personID)
+	if _, err := parsePersonID(id); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid person ID %q, it must not be empty or contain '/'", personID))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, id)
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &FullNameFunction{}
+
+// NewFullNameFunction is a helper function to simplify the provider implementation.
+func NewFullNameFunction() function.Function {
+	return &FullNameFunction{}
+}
+
+// FullNameFunction is the full_name function implementation.
+type FullNameFunction struct{}
+
+// Metadata returns the function name.
+func (f *FullNameFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "full_name"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *FullNameFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Returns the full name of a person.",
+		Description: "Returns the name of a person the way it is addressed, the same as the display_name of a persondb_person: " +
+			"the prefix, the preferred name or else the given name, the middle name, the family name and the suffix, separated by spaces.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name: "name",
+				Description: "Name of the person, such as the name of a persondb_person or an object with only some of its attributes: " +
+					"prefix, given, middle, family, suffix and preferred. The family name is required, the other attributes default to empty.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run joins the name parts.
+func (f *FullNameFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var name types.Dynamic
+
+	resp.Error = req.Arguments.Get(ctx, &name)
+	if resp.Error != nil {
+		return
+	}
+
+	person, err := personNameFromDynamic(name)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, displayName(person))
+}
+
+// personNameFromDynamic returns a person with the name parts of an object or
+// map of strings, with the attributes of the name of a persondb_person.
+func personNameFromDynamic(name types.Dynamic) (*persondbclient.Person, error) {
+	var attributes map[string]attr.Value
+	switch value := name.UnderlyingValue().(type) {
+	case types.Object:
+		attributes = value.Attributes()
+	case types.Map:
+		attributes = value.Elements()
+	default:
+		return nil, fmt.Errorf("name must be an object, got %s", name.UnderlyingValue().Type(context.Background()))
+	}
+
+	parts := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		if _, ok := personNameAttrTypes[key]; !ok {
+			return nil, fmt.Errorf("unsupported name attribute %q, expected prefix, given, middle, family, suffix or preferred", key)
+		}
+		part, ok := value.(types.String)
+		if !ok {
+			return nil, fmt.Errorf("name attribute %q must be a string", key)
+		}
+		parts[key] = part.ValueString()
+	}
+	if parts["family"] == "" {
+		return nil, errors.New("name must have a family name")
+	}
+
+	return &persondbclient.Person{
+		NamePrefix:    parts["prefix"],
+		FirstName:     parts["given"],
+		MiddleName:    parts["middle"],
+		LastName:      parts["family"],
+		NameSuffix:    parts["suffix"],
+		PreferredName: parts["preferred"],
+	}, nil
+}
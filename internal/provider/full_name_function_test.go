@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+func TestFullNameFunction(t *testing.T) {
+	object := func(attributes map[string]attr.Value) types.Dynamic {
+		attrTypes := make(map[string]attr.Type, len(attributes))
+		for key, value := range attributes {
+			attrTypes[key] = value.Type(context.Background())
+		}
+		return types.DynamicValue(types.ObjectValueMust(attrTypes, attributes))
+	}
+
+	testCases := map[string]struct {
+		name      types.Dynamic
+		want      string
+		wantError bool
+	}{
+		"name of a persondb_person": {
+			name: types.DynamicValue(nameValue(&persondbclient.Person{NamePrefix: "Dr.", FirstName: "Jan", LastName: "Peeters", PreferredName: "Jo"})),
+			want: "Dr. Jo Peeters",
+		},
+		"partial object": {
+			name: object(map[string]attr.Value{"given": types.StringValue("Jan"), "family": types.StringValue("Peeters")}),
+			want: "Jan Peeters",
+		},
+		"null attribute": {
+			name: object(map[string]attr.Value{"given": types.StringNull(), "family": types.StringValue("Peeters")}),
+			want: "Peeters",
+		},
+		"map": {
+			name: types.DynamicValue(types.MapValueMust(types.StringType, map[string]attr.Value{"family": types.StringValue("Peeters")})),
+			want: "Peeters",
+		},
+		"missing family name": {
+			name:      object(map[string]attr.Value{"given": types.StringValue("Jan")}),
+			wantError: true,
+		},
+		"unsupported attribute": {
+			name:      object(map[string]attr.Value{"family": types.StringValue("Peeters"), "nickname": types.StringValue("Jos")}),
+			wantError: true,
+		},
+		"attribute not a string": {
+			name:      object(map[string]attr.Value{"family": types.Int64Value(1)}),
+			wantError: true,
+		},
+		"not an object": {
+			name:      types.DynamicValue(types.StringValue("Jan Peeters")),
+			wantError: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			req := function.RunRequest{Arguments: function.NewArgumentsData([]attr.Value{testCase.name})}
+			resp := function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+			NewFullNameFunction().Run(ctx, req, &resp)
+
+			if (resp.Error != nil) != testCase.wantError {
+				t.Fatalf("got error %v, want error %t", resp.Error, testCase.wantError)
+			}
+			if testCase.wantError {
+				return
+			}
+			if got := resp.Result.Value(); !got.Equal(types.StringValue(testCase.want)) {
+				t.Errorf("got %s, want %q", got, testCase.want)
+			}
+		})
+	}
+}
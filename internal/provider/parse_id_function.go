@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &ParseIDFunction{}
+
+// NewParseIDFunction is a helper function to simplify the provider implementation.
+func NewParseIDFunction() function.Function {
+	return &ParseIDFunction{}
+}
+
+// ParseIDFunction is the parse_id function implementation.
+type ParseIDFunction struct{}
+
+// Metadata returns the function name.
+func (f *ParseIDFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_id"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *ParseIDFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Returns the person ID of a persondb_person ID.",
+		Description: "Returns the person ID of a persondb_person ID in one of the forms \"/person/1\", \"persondb:person:1\" or \"1\".",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "id",
+				Description: "ID of the person, for example the id attribute of a persondb_person resource.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run parses the ID.
+func (f *ParseIDFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	personID, err := parsePersonID(id)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, personID)
+}
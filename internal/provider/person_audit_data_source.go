@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PersonAuditDataSource{}
+	_ datasource.DataSourceWithConfigure = &PersonAuditDataSource{}
+)
+
+// NewPersonAuditDataSource is a helper function to simplify the provider implementation.
+func NewPersonAuditDataSource() datasource.DataSource {
+	return &PersonAuditDataSource{}
+}
+
+// PersonAuditDataSource is the data source implementation.
+type PersonAuditDataSource struct {
+	client persondbclient.PersonStore
+}
+
+// PersonAuditDataSourceModel maps the data source schema data.
+type PersonAuditDataSourceModel struct {
+	ID       types.String            `tfsdk:"id"`
+	PersonID types.String            `tfsdk:"person_id"`
+	Entries  []PersonAuditEntryModel `tfsdk:"entries"`
+}
+
+// PersonAuditEntryModel maps one audit log entry.
+type PersonAuditEntryModel struct {
+	ID           types.Int64  `tfsdk:"id"`
+	Timestamp    types.String `tfsdk:"timestamp"`
+	Operation    types.String `tfsdk:"operation"`
+	Actor        types.String `tfsdk:"actor"`
+	ClaimedActor types.String `tfsdk:"claimed_actor"`
+	OldValues    types.String `tfsdk:"old_values"`
+	NewValues    types.String `tfsdk:"new_values"`
+}
+
+// Metadata returns the data source type name.
+func (d *PersonAuditDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_person_audit"
+}
+
+// Schema defines the schema for the data source.
+func (d *PersonAuditDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "History of the changes made to a person, oldest first. The history is kept after the person is deleted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"person_id": schema.StringAttribute{
+				Description: "Person ID in the database.",
+				Required:    true,
+			},
+			"entries": schema.ListNestedAttribute{
+				Description: "Audit log entries of the person.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "Sequence number of the entry.",
+							Computed:    true,
+						},
+						"timestamp": schema.StringAttribute{
+							Description: "Time of the change in RFC 3339 format.",
+							Computed:    true,
+						},
+						"operation": schema.StringAttribute{
+							Description: "Operation: \"create\", \"update\", \"delete\", \"restore\" when a soft deleted person is created again, or \"purge\" when its tombstone is removed.",
+							Computed:    true,
+						},
+						"actor": schema.StringAttribute{
+							Description: "Who made the change: the provider actor, or the actor of the persondb-server for changes made through its API.",
+							Computed:    true,
+						},
+						"claimed_actor": schema.StringAttribute{
+							Description: "Actor the client of a persondb-server claimed to be, as sent in the X-PersonDB-Actor header. " +
+								"It is not verified by the server. Null when no actor was claimed.",
+							Computed: true,
+						},
+						"old_values": schema.StringAttribute{
+							Description: "JSON encoded person before the change, null for a create or restore.",
+							Computed:    true,
+						},
+						"new_values": schema.StringAttribute{
+							Description: "JSON encoded person after the change, null for a delete or purge.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *PersonAuditDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PersonAuditDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	personId := data.PersonID.ValueString()
+	entries, err := d.client.ListAuditEntries(ctx, personId)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading person audit log",
+			"Could not read audit log, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("/person/" + personId + "/audit")
+	data.Entries = make([]PersonAuditEntryModel, 0, len(entries))
+	for _, entry := range entries {
+		oldValues, err := auditValuesString(entry.OldValues)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading person audit log", "Could not encode old values: "+err.Error())
+			return
+		}
+		newValues, err := auditValuesString(entry.NewValues)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading person audit log", "Could not encode new values: "+err.Error())
+			return
+		}
+		data.Entries = append(data.Entries, PersonAuditEntryModel{
+			ID:           types.Int64Value(entry.ID),
+			Timestamp:    types.StringValue(entry.Timestamp.Format(time.RFC3339)),
+			Operation:    types.StringValue(entry.Operation),
+			Actor:        types.StringValue(entry.Actor),
+			ClaimedActor: claimedActorString(entry.ClaimedActor),
+			OldValues:    oldValues,
+			NewValues:    newValues,
+		})
+	}
+
+	// Set data
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// claimedActorString returns the claimed actor of an audit entry, null when
+// none was claimed.
+func claimedActorString(claimedActor string) types.String {
+	if claimedActor == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(claimedActor)
+}
+
+// auditValuesString JSON encodes the person values of an audit entry.
+func auditValuesString(person *persondbclient.Person) (types.String, error) {
+	if person == nil {
+		return types.StringNull(), nil
+	}
+	content, err := json.Marshal(person)
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(string(content)), nil
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PersonAuditDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*persondbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *persondbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+}
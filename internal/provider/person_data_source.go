@@ -2,10 +2,12 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
 )
@@ -22,15 +24,20 @@ func NewPersonDataSource() datasource.DataSource {
 
 // PersonDataSource is the data source implementation.
 type PersonDataSource struct {
-	client *persondbclient.Client
+	client persondbclient.PersonStore
 }
 
 // PersonDataSourceModel maps the data source schema data.
 type PersonDataSourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	PersonID  types.String `tfsdk:"person_id"`
-	LastName  types.String `tfsdk:"last_name"`
-	FirstName types.String `tfsdk:"first_name"`
+	ID           types.String `tfsdk:"id"`
+	PersonID     types.String `tfsdk:"person_id"`
+	Name         types.Object `tfsdk:"name"`
+	DisplayName  types.String `tfsdk:"display_name"`
+	Email        types.String `tfsdk:"email"`
+	BirthDate    types.String `tfsdk:"birth_date"`
+	PhoneNumbers types.Set    `tfsdk:"phone_numbers"`
+	Tags         types.Map    `tfsdk:"tags"`
+	Addresses    types.List   `tfsdk:"addresses"`
 }
 
 // Metadata returns the data source type name.
@@ -49,14 +56,54 @@ func (d *PersonDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Description: "Person ID in the database.",
 				Required:    true,
 			},
-			"last_name": schema.StringAttribute{
-				Description: "Last name of the person.",
+			"name":         nameDataSourceAttribute(),
+			"display_name": displayNameDataSourceAttribute(),
+			"email": schema.StringAttribute{
+				Description: "Email address of the person.",
 				Computed:    true,
 			},
-			"first_name": schema.StringAttribute{
-				Description: "First name of the person.",
+			"birth_date": schema.StringAttribute{
+				Description: "Birth date of the person, as an RFC 3339 date such as \"1990-12-31\".",
 				Computed:    true,
 			},
+			"phone_numbers": schema.SetAttribute{
+				Description: "Phone numbers of the person.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the person.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"addresses": schema.ListNestedAttribute{
+				Description: "Postal addresses of the person.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Kind of address: \"home\", \"work\" or \"other\".",
+							Computed:    true,
+						},
+						"street": schema.StringAttribute{
+							Description: "Street and house number.",
+							Computed:    true,
+						},
+						"city": schema.StringAttribute{
+							Description: "City.",
+							Computed:    true,
+						},
+						"postal_code": schema.StringAttribute{
+							Description: "Postal code.",
+							Computed:    true,
+						},
+						"country": schema.StringAttribute{
+							Description: "Country, as an ISO 3166-1 alpha-2 code such as \"BE\".",
+							Computed:    true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -72,7 +119,15 @@ func (d *PersonDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	personId := data.PersonID.ValueString()
-	lastName, firstName, err := d.client.ReadPerson(personId)
+	person, err := d.client.ReadPerson(ctx, personId)
+	if errors.Is(err, persondbclient.ErrPersonNotFound) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("person_id"),
+			"Person not found",
+			"No person with person_id '"+personId+"' exists in the database.",
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading person",
@@ -81,25 +136,73 @@ func (d *PersonDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	data.ID = types.StringValue("/person/" + personId)
-	data.LastName = types.StringValue(lastName)
-	data.FirstName = types.StringValue(firstName)
-
-	// Check if firstName is empty and set it to null if it is (because it is optional)
-	//if firstName == "" {
-	//	data.FirstName = types.StringNull()
-	//} else {
-	//	data.FirstName = types.StringValue(firstName)
-	//}
+	data.ID = types.StringValue(formatPersonID(personId))
+	data.Name = nameValue(person)
+	data.DisplayName = types.StringValue(displayName(person))
+	data.Email = types.StringValue(person.Email)
+	data.BirthDate = types.StringValue(person.BirthDate)
+	phoneNumbers, diags := types.SetValueFrom(ctx, types.StringType, emptyIfNil(person.PhoneNumbers))
+	resp.Diagnostics.Append(diags...)
+	data.PhoneNumbers = phoneNumbers
+	tags, diags := tagsValue(ctx, person.Tags)
+	resp.Diagnostics.Append(diags...)
+	data.Tags = tags
+	addresses, diags := addressesValue(ctx, person.Addresses)
+	resp.Diagnostics.Append(diags...)
+	data.Addresses = addresses
 
 	// Set data
-	diags := resp.State.Set(ctx, &data)
+	diags = resp.State.Set(ctx, &data)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
 
+// nameDataSourceAttribute returns the schema of the name of a person read by a
+// data source.
+func nameDataSourceAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description: "Name of the person.",
+		Computed:    true,
+		Attributes: map[string]schema.Attribute{
+			"prefix": schema.StringAttribute{
+				Description: "Honorific prefix, such as \"Dr.\".",
+				Computed:    true,
+			},
+			"given": schema.StringAttribute{
+				Description: "Given name.",
+				Computed:    true,
+			},
+			"middle": schema.StringAttribute{
+				Description: "Middle names.",
+				Computed:    true,
+			},
+			"family": schema.StringAttribute{
+				Description: "Family name.",
+				Computed:    true,
+			},
+			"suffix": schema.StringAttribute{
+				Description: "Suffix, such as \"Jr.\".",
+				Computed:    true,
+			},
+			"preferred": schema.StringAttribute{
+				Description: "Name the person prefers to be called by, used instead of the given name in display_name.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// displayNameDataSourceAttribute returns the schema of the display name of a
+// person read by a data source.
+func displayNameDataSourceAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: "Name the person is addressed with: the prefix, the preferred or else the given name, the middle names, the family name and the suffix.",
+		Computed:    true,
+	}
+}
+
 // Configure adds the provider configured client to the data source.
 func (d *PersonDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Add a nil check when handling ProviderData because Terraform
@@ -108,15 +211,15 @@ func (d *PersonDataSource) Configure(_ context.Context, req datasource.Configure
 		return
 	}
 
-	client, ok := req.ProviderData.(*persondbclient.Client)
+	providerData, ok := req.ProviderData.(*persondbProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *persondbclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *persondbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.client
 }
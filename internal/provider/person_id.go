@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// personIDPrefix is the prefix of the canonical person resource ID
+	// "/person/<person_id>".
+	personIDPrefix = "/person/"
+
+	// personURNPrefix is the prefix of the URN-style person resource ID
+	// "persondb:person:<person_id>".
+	personURNPrefix = "persondb:person:"
+)
+
+// formatPersonID returns the canonical resource ID of a person.
+func formatPersonID(personID string) string {
+	return personIDPrefix + personID
+}
+
+// parsePersonID returns the person ID of a resource ID in one of the
+// supported forms: "/person/1", "persondb:person:1" or plain "1".
+func parsePersonID(id string) (string, error) {
+	personID := id
+	switch {
+	case strings.HasPrefix(id, personIDPrefix):
+		personID = strings.TrimPrefix(id, personIDPrefix)
+	case strings.HasPrefix(id, personURNPrefix):
+		personID = strings.TrimPrefix(id, personURNPrefix)
+	}
+	if !validPersonID(personID) {
+		return "", fmt.Errorf("invalid person ID %q, expected one of '/person/<person_id>', 'persondb:person:<person_id>' or '<person_id>', for example '/person/1', 'persondb:person:1' or '1'", id)
+	}
+	return personID, nil
+}
+
+// validPersonID reports whether personID can be used in a resource ID: it
+// must not be empty or contain a "/".
+func validPersonID(personID string) bool {
+	return personID != "" && !strings.Contains(personID, "/")
+}
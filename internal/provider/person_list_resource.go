@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &PersonListResource{}
+	_ list.ListResourceWithConfigure = &PersonListResource{}
+)
+
+// NewPersonListResource is a helper function to simplify the provider implementation.
+func NewPersonListResource() list.ListResource {
+	return &PersonListResource{}
+}
+
+// PersonListResource is the list resource implementation, it lists the
+// persons that can be imported with the persondb_person resource.
+type PersonListResource struct {
+	client      persondbclient.PersonStore
+	defaultTags map[string]string
+}
+
+// PersonListResourceModel maps the list resource config schema data.
+type PersonListResourceModel struct {
+	FamilyNamePrefix types.String      `tfsdk:"family_name_prefix"`
+	GivenName        types.String      `tfsdk:"given_name"`
+	Tags             map[string]string `tfsdk:"tags"`
+}
+
+// Metadata returns the list resource type name, which matches the resource.
+func (r *PersonListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_person"
+}
+
+// ListResourceConfigSchema defines the schema of the list block config.
+func (r *PersonListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the persons in the database, for discovery with 'terraform query'.",
+		Attributes: map[string]schema.Attribute{
+			"family_name_prefix": schema.StringAttribute{
+				Description: "Only list persons whose family name starts with this prefix, case-sensitively.",
+				Optional:    true,
+			},
+			"given_name": schema.StringAttribute{
+				Description: "Only list persons with exactly this given name.",
+				Optional:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Only list persons having all of these tags with the same values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+		},
+	}
+}
+
+// List streams the persons matching the list block config.
+func (r *PersonListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config PersonListResourceModel
+
+	// Read list block config data into the model
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	persons, err := r.client.ListPersons(ctx, persondbclient.ListFilter{
+		LastNamePrefix: config.FamilyNamePrefix.ValueString(),
+		FirstName:      config.GivenName.ValueString(),
+		Tags:           config.Tags,
+		Limit:          int(req.Limit),
+	})
+	if err != nil {
+		diags.AddError(
+			"Error listing persons",
+			"Could not list persons, unexpected error: "+err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for _, person := range persons {
+			result := req.NewListResult(ctx)
+			result.DisplayName = fmt.Sprintf("%s (%s)", displayName(&person), person.PersonID)
+			result.Diagnostics.Append(setPersonIdentity(ctx, result.Identity, person.PersonID)...)
+
+			if req.IncludeResource {
+				data := PersonResourceModel{
+					ID:       types.StringValue(formatPersonID(person.PersonID)),
+					Timeouts: nullTimeouts(),
+				}
+				result.Diagnostics.Append(setPersonModel(ctx, &data, &person, r.defaultTags)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, data)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// Configure adds the provider configured client to the list resource.
+func (r *PersonListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*persondbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *persondbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.client
+	r.defaultTags = providerData.defaultTags
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// PersonNameModel maps the name attribute schema data.
+type PersonNameModel struct {
+	Prefix    types.String `tfsdk:"prefix"`
+	Given     types.String `tfsdk:"given"`
+	Middle    types.String `tfsdk:"middle"`
+	Family    types.String `tfsdk:"family"`
+	Suffix    types.String `tfsdk:"suffix"`
+	Preferred types.String `tfsdk:"preferred"`
+}
+
+// personNameAttrTypes are the attribute types of a name.
+var personNameAttrTypes = map[string]attr.Type{
+	"prefix":    types.StringType,
+	"given":     types.StringType,
+	"middle":    types.StringType,
+	"family":    types.StringType,
+	"suffix":    types.StringType,
+	"preferred": types.StringType,
+}
+
+// nameValue returns the name of the person as an object value.
+func nameValue(person *persondbclient.Person) types.Object {
+	return types.ObjectValueMust(personNameAttrTypes, map[string]attr.Value{
+		"prefix":    types.StringValue(person.NamePrefix),
+		"given":     types.StringValue(person.FirstName),
+		"middle":    types.StringValue(person.MiddleName),
+		"family":    types.StringValue(person.LastName),
+		"suffix":    types.StringValue(person.NameSuffix),
+		"preferred": types.StringValue(person.PreferredName),
+	})
+}
+
+// setPersonName sets the name parts of the person to the name value.
+func setPersonName(ctx context.Context, person *persondbclient.Person, name types.Object) diag.Diagnostics {
+	var model PersonNameModel
+	diags := name.As(ctx, &model, basetypes.ObjectAsOptions{})
+	person.NamePrefix = model.Prefix.ValueString()
+	person.FirstName = model.Given.ValueString()
+	person.MiddleName = model.Middle.ValueString()
+	person.LastName = model.Family.ValueString()
+	person.NameSuffix = model.Suffix.ValueString()
+	person.PreferredName = model.Preferred.ValueString()
+	return diags
+}
+
+// displayName returns the name a person is addressed with: the preferred
+// name replaces the given name, and the parts that are set are joined with
+// spaces.
+func displayName(person *persondbclient.Person) string {
+	given := person.FirstName
+	if person.PreferredName != "" {
+		given = person.PreferredName
+	}
+	var parts []string
+	for _, part := range []string{person.NamePrefix, given, person.MiddleName, person.LastName, person.NameSuffix} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// checkNameLength reports the known name parts that are longer than
+// maxLength characters.
+func checkNameLength(name types.Object, maxLength int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if name.IsNull() || name.IsUnknown() {
+		return diags
+	}
+	for part, value := range name.Attributes() {
+		value, ok := value.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+		if length := utf8.RuneCountInString(value.ValueString()); int64(length) > maxLength {
+			diags.AddAttributeError(
+				path.Root("name").AtName(part),
+				"Name too long",
+				fmt.Sprintf("The %s name is %d characters long, the provider allows at most %d characters (max_name_length).", part, length, maxLength),
+			)
+		}
+	}
+	return diags
+}
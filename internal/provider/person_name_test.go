@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+func TestDisplayName(t *testing.T) {
+	testCases := map[string]struct {
+		person persondbclient.Person
+		want   string
+	}{
+		"family name": {
+			person: persondbclient.Person{LastName: "Peeters"},
+			want:   "Peeters",
+		},
+		"given and family name": {
+			person: persondbclient.Person{FirstName: "Jan", LastName: "Peeters"},
+			want:   "Jan Peeters",
+		},
+		"all parts": {
+			person: persondbclient.Person{NamePrefix: "Dr.", FirstName: "Jan", MiddleName: "Pieter", LastName: "Peeters", NameSuffix: "Jr."},
+			want:   "Dr. Jan Pieter Peeters Jr.",
+		},
+		"preferred name replaces the given name": {
+			person: persondbclient.Person{FirstName: "Johannes", PreferredName: "Jan", LastName: "Peeters"},
+			want:   "Jan Peeters",
+		},
+		"preferred name without a given name": {
+			person: persondbclient.Person{PreferredName: "Jan", LastName: "Peeters"},
+			want:   "Jan Peeters",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := displayName(&testCase.person); got != testCase.want {
+				t.Errorf("got %q, want %q", got, testCase.want)
+			}
+		})
+	}
+}
@@ -2,17 +2,27 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
@@ -21,6 +31,8 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PersonResource{}
 var _ resource.ResourceWithImportState = &PersonResource{}
+var _ resource.ResourceWithIdentity = &PersonResource{}
+var _ resource.ResourceWithModifyPlan = &PersonResource{}
 
 // NewPersonResource is a helper function to simplify the provider implementation.
 func NewPersonResource() resource.Resource {
@@ -29,15 +41,51 @@ func NewPersonResource() resource.Resource {
 
 // PersonResource is the resource implementation.
 type PersonResource struct {
-	client *persondbclient.Client
+	client              persondbclient.PersonStore
+	actor               string
+	existingPersonCheck string
+	adoptExisting       bool
+	timeouts            persondbTimeouts
+	defaultTags         map[string]string
+	maxNameLength       int64
 }
 
 // PersonResourceModel maps the resource schema data.
 type PersonResourceModel struct {
-	ID        types.String `tfsdk:"id"`
-	PersonID  types.String `tfsdk:"person_id"`
-	LastName  types.String `tfsdk:"last_name"`
-	FirstName types.String `tfsdk:"first_name"`
+	ID           types.String   `tfsdk:"id"`
+	PersonID     types.String   `tfsdk:"person_id"`
+	Name         types.Object   `tfsdk:"name"`
+	DisplayName  types.String   `tfsdk:"display_name"`
+	Email        types.String   `tfsdk:"email"`
+	BirthDate    types.String   `tfsdk:"birth_date"`
+	PhoneNumbers types.Set      `tfsdk:"phone_numbers"`
+	Tags         types.Map      `tfsdk:"tags"`
+	TagsAll      types.Map      `tfsdk:"tags_all"`
+	Addresses    types.List     `tfsdk:"address"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// PersonAddressModel maps the address block schema data.
+type PersonAddressModel struct {
+	Type       types.String `tfsdk:"type"`
+	Street     types.String `tfsdk:"street"`
+	City       types.String `tfsdk:"city"`
+	PostalCode types.String `tfsdk:"postal_code"`
+	Country    types.String `tfsdk:"country"`
+}
+
+// personAddressAttrTypes are the attribute types of an address.
+var personAddressAttrTypes = map[string]attr.Type{
+	"type":        types.StringType,
+	"street":      types.StringType,
+	"city":        types.StringType,
+	"postal_code": types.StringType,
+	"country":     types.StringType,
+}
+
+// PersonResourceIdentityModel maps the resource identity schema data.
+type PersonResourceIdentityModel struct {
+	PersonID types.String `tfsdk:"person_id"`
 }
 
 // Metadata returns the resource type name.
@@ -48,6 +96,7 @@ func (r *PersonResource) Metadata(ctx context.Context, req resource.MetadataRequ
 // Schema defines the schema for the resource.
 func (r *PersonResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version: personResourceSchemaVersion,
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
 				Computed: true,
@@ -56,28 +105,165 @@ func (r *PersonResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"person_id": schema.StringAttribute{
-				Description: "Person ID in the database.",
+				Description: "Person ID in the database. Must not be empty or contain a \"/\".",
 				Required:    true,
+				Validators: []validator.String{
+					personIDValidator{},
+				},
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"last_name": schema.StringAttribute{
-				Description: "Last name of the person.",
+			"name": schema.SingleNestedAttribute{
+				Description: "Name of the person. The length of each part is limited by max_name_length of the provider.",
 				Required:    true,
+				Attributes: map[string]schema.Attribute{
+					"prefix": nameStringAttribute("Honorific prefix, such as \"Dr.\"."),
+					"given":  nameStringAttribute("Given name."),
+					"middle": nameStringAttribute("Middle names."),
+					"family": schema.StringAttribute{
+						Description: "Family name.",
+						Required:    true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"suffix":    nameStringAttribute("Suffix, such as \"Jr.\"."),
+					"preferred": nameStringAttribute("Name the person prefers to be called by, used instead of the given name in display_name."),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				Description: "Name the person is addressed with: the prefix, the preferred or else the given name, the middle names, the family name and the suffix.",
+				Computed:    true,
+			},
+			"email": schema.StringAttribute{
+				Description: "Email address of the person, unique among all persons compared case-insensitively.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
 				Validators: []validator.String{
-					stringvalidator.LengthBetween(1, 30),
+					stringvalidator.RegexMatches(emailRegexp, "must be a valid email address"),
 				},
 			},
-			"first_name": schema.StringAttribute{
-				Description: "First name of the person.",
+			"birth_date": schema.StringAttribute{
+				Description: "Birth date of the person, as an RFC 3339 date such as \"1990-12-31\".",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString(""),
 				Validators: []validator.String{
-					stringvalidator.LengthAtLeast(1),
+					dateValidator{},
+				},
+			},
+			"phone_numbers": schema.SetAttribute{
+				Description: "Phone numbers of the person, of digits, spaces, dashes and parentheses with an optional leading \"+\".",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{})),
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(
+						stringvalidator.RegexMatches(phoneNumberRegexp, "must be a phone number"),
+					),
+				},
+			},
+			"tags": schema.MapAttribute{
+				Description: "Tags of the person, such as team, cost_center or location. Tags with the same key as a provider default tag override it.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     mapdefault.StaticValue(types.MapValueMust(types.StringType, map[string]attr.Value{})),
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "All tags of the person: the provider default_tags merged with tags.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"address": schema.ListNestedBlock{
+				Description: "Postal addresses of the person, in the order they are given.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Description: "Kind of address: \"home\", \"work\" or \"other\".",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.OneOf("home", "work", "other"),
+							},
+						},
+						"street": schema.StringAttribute{
+							Description: "Street and house number.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"city": schema.StringAttribute{
+							Description: "City.",
+							Required:    true,
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"postal_code": schema.StringAttribute{
+							Description: "Postal code.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+							},
+						},
+						"country": schema.StringAttribute{
+							Description: "Country, as an upper case ISO 3166-1 alpha-2 code such as \"BE\".",
+							Required:    true,
+							Validators: []validator.String{
+								countryCodeValidator{},
+							},
+						},
+					},
 				},
 			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create:            true,
+				Read:              true,
+				Update:            true,
+				Delete:            true,
+				CreateDescription: "Timeout of creating the person, as a duration string such as \"5m\". Defaults to create in the provider default_timeouts block.",
+				ReadDescription:   "Timeout of reading the person, as a duration string such as \"5m\". Defaults to read in the provider default_timeouts block.",
+				UpdateDescription: "Timeout of updating the person, as a duration string such as \"5m\". Defaults to update in the provider default_timeouts block.",
+				DeleteDescription: "Timeout of deleting the person, as a duration string such as \"5m\". Defaults to delete in the provider default_timeouts block.",
+			}),
+		},
+	}
+}
+
+// nameStringAttribute returns the schema of an optional name part.
+func nameStringAttribute(description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: description,
+		Optional:    true,
+		Computed:    true,
+		Default:     stringdefault.StaticString(""),
+		Validators: []validator.String{
+			stringvalidator.LengthAtLeast(1),
+		},
+	}
+}
+
+// IdentitySchema defines the identity of the resource. Terraform uses it to
+// track the person across state operations, to import it with an identity in
+// an import block, and it is returned by the persondb_person list resource.
+func (r *PersonResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"person_id": identityschema.StringAttribute{
+				Description:       "Person ID in the database.",
+				RequiredForImport: true,
+			},
 		},
 	}
 }
@@ -88,22 +274,124 @@ func (r *PersonResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*persondbclient.Client)
+	providerData, ok := req.ProviderData.(*persondbProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *persondbclient.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *persondbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.client
+	r.actor = providerData.actor
+	r.existingPersonCheck = providerData.existingPersonCheck
+	r.adoptExisting = providerData.adoptExisting
+	r.timeouts = providerData.timeouts
+	r.defaultTags = providerData.defaultTags
+	r.maxNameLength = providerData.maxNameLength
+}
+
+// ModifyPlan checks the name against max_name_length, plans display_name,
+// merges the provider default tags into tags_all, and reports a person
+// planned for creation that already exists at plan time, instead of failing
+// the apply after other resources may have changed. With adopt_existing the
+// person is taken over on apply, which is only reported as a warning.
+func (r *PersonResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan PersonResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The length limit is a provider setting, so it cannot be a schema validator
+	maxNameLength := r.maxNameLength
+	if maxNameLength == 0 {
+		// The provider is not configured yet, check the default limit
+		maxNameLength = defaultMaxNameLength
+	}
+	resp.Diagnostics.Append(checkNameLength(plan.Name, maxNameLength)...)
+	displayNameValue := types.StringUnknown()
+	if isKnownObject(plan.Name) {
+		var person persondbclient.Person
+		resp.Diagnostics.Append(setPersonName(ctx, &person, plan.Name)...)
+		displayNameValue = types.StringValue(displayName(&person))
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("display_name"), displayNameValue)...)
+
+	// Plan tags_all, so a change of the provider default tags updates the person
+	tagsAll, diags := mergeTags(r.defaultTags, plan.Tags)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), tagsAll)...)
+	// The existence check needs the configured provider and a known person_id
+	if resp.Diagnostics.HasError() || r.client == nil || plan.PersonID.IsUnknown() {
+		return
+	}
+	personID := plan.PersonID.ValueString()
+
+	// Only a new person, or a replacement with another person_id, is created
+	if !req.State.Raw.IsNull() {
+		var state PersonResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() || state.PersonID.ValueString() == personID {
+			return
+		}
+	}
+
+	// The existence check reads the person, so it is bounded by the read timeout
+	readTimeout, diags := plan.Timeouts.Read(ctx, r.timeouts.read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	exists, err := r.client.CheckPersonExists(ctx, personID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error planning person",
+			"Could not check if person with person_id '"+personID+"' exists, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if !exists {
+		return
+	}
+
+	if r.adoptExisting {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("person_id"),
+			"Person will be adopted",
+			"Person with person_id '"+personID+"' already exists in the database. "+
+				"Because adopt_existing is enabled, it will be managed by Terraform and updated to the planned values.",
+		)
+		return
+	}
+
+	summary := "Person already exists"
+	detail := "Person with person_id '" + personID + "' already exists in the database, so creating it will fail. " +
+		"Import it to manage it in Terraform, with an import block with 'identity = { person_id = \"" + personID + "\" }' " +
+		"or with 'terraform import <resource address> /person/" + personID + "'."
+	if r.existingPersonCheck == existingPersonCheckWarning {
+		resp.Diagnostics.AddAttributeWarning(path.Root("person_id"), summary, detail)
+	} else {
+		resp.Diagnostics.AddAttributeError(path.Root("person_id"), summary, detail)
+	}
 }
 
 func (r *PersonResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data PersonResourceModel
 
+	// Record the configured actor in the audit log
+	ctx = persondbclient.ContextWithActor(ctx, r.actor)
+
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -111,13 +399,25 @@ func (r *PersonResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	// Bound the duration of the operation with the configured timeout
+	createTimeout, diags := data.Timeouts.Create(ctx, r.timeouts.create)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	personID := data.PersonID.ValueString()
-	lastName := data.LastName.ValueString()
-	firstName := data.FirstName.ValueString()
+	person, diags := personFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
 	// Check if the person already exists, if yes return a message the resource already exists and needs to be imported
-	exists, err := r.client.CheckPersonExists(personID)
+	exists, err := r.client.CheckPersonExists(ctx, personID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating person",
@@ -125,7 +425,7 @@ func (r *PersonResource) Create(ctx context.Context, req resource.CreateRequest,
 		)
 		return
 	}
-	if exists {
+	if exists && !r.adoptExisting {
 		resp.Diagnostics.AddError(
 			"Error creating person",
 			"Person with person_id '"+personID+"' already exists. Use 'terraform import' to manage it in Terraform.",
@@ -133,8 +433,24 @@ func (r *PersonResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Create new person
-	err = r.client.CreatePerson(personID, lastName, firstName)
+	// Create new person, or adopt the existing person with the planned values
+	var created *persondbclient.Person
+	if exists {
+		created, err = r.adoptPerson(ctx, person)
+	} else {
+		created, err = r.client.CreatePerson(ctx, person)
+	}
+	if errors.Is(err, persondbclient.ErrPersonAlreadyExists) {
+		resp.Diagnostics.AddError(
+			"Error creating person",
+			"Person with person_id '"+personID+"' already exists. Use 'terraform import' to manage it in Terraform.",
+		)
+		return
+	}
+	if errors.Is(err, persondbclient.ErrEmailAlreadyExists) {
+		addEmailAlreadyExistsError(&resp.Diagnostics, person.Email)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating person",
@@ -142,16 +458,38 @@ func (r *PersonResource) Create(ctx context.Context, req resource.CreateRequest,
 		)
 		return
 	}
+	if exists {
+		resp.Diagnostics.AddWarning(
+			"Adopted existing person",
+			"Person with person_id '"+personID+"' already existed in the database and is now managed by Terraform. "+
+				"It was updated to the planned values.",
+		)
+	}
 
 	// Save ID with the format "/person/<person_id>" to Terraform state
-	data.ID = types.StringValue("/person/" + personID)
+	data.ID = types.StringValue(formatPersonID(personID))
+
+	// Remember the version of the person for the conflict check on update
+	resp.Diagnostics.Append(setPrivateVersion(ctx, resp.Private, created.Version)...)
 
 	// Write logs using the tflog package
 	// Documentation: https://terraform.io/plugin/log
 	tflog.Trace(ctx, "created a resource")
 
-	// Save data into Terraform state
+	// Save data and identity into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setPersonIdentity(ctx, resp.Identity, personID)...)
+}
+
+// adoptPerson takes over an existing person by updating it to the planned
+// values, guarded by the version it had when it was read.
+func (r *PersonResource) adoptPerson(ctx context.Context, person persondbclient.Person) (*persondbclient.Person, error) {
+	current, err := r.client.ReadPerson(ctx, person.PersonID)
+	if err != nil {
+		return nil, err
+	}
+	person.Version = current.Version
+	return r.client.UpdatePerson(ctx, person)
 }
 
 func (r *PersonResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -164,41 +502,55 @@ func (r *PersonResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	parts := strings.Split(data.ID.ValueString(), "/")
-	if len(parts) != 3 || parts[1] != "person" {
-		resp.Diagnostics.AddError(
+	// Bound the duration of the operation with the configured timeout
+	readTimeout, diags := data.Timeouts.Read(ctx, r.timeouts.read)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	personID, err := parsePersonID(data.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("id"),
 			"Invalid ID format",
-			fmt.Sprintf("Expected '/person/<person_id>', got: %s", data.ID.ValueString()),
+			err.Error(),
 		)
 		return
 	}
 
-	personID := parts[2]
-	lastName, firstName, err := r.client.ReadPerson(personID)
-	if err != nil {
+	person, err := r.client.ReadPerson(ctx, personID)
+	if errors.Is(err, persondbclient.ErrPersonNotFound) {
 		// Person could not be found, so we call the RemoveResource method to enforce new resource creation
 		resp.State.RemoveResource(ctx)
 		return
 	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading person",
+			"Could not read person with person_id '"+personID+"', unexpected error: "+err.Error(),
+		)
+		return
+	}
 
-	data.PersonID = types.StringValue(personID)
-	data.LastName = types.StringValue(lastName)
-	data.FirstName = types.StringValue(firstName)
+	resp.Diagnostics.Append(setPersonModel(ctx, &data, person, r.defaultTags)...)
 
-	// Check if firstName is empty and set it to null if it is (because it is optional)
-	//if firstName == "" {
-	//	data.FirstName = types.StringNull()
-	//} else {
-	//	data.FirstName = types.StringValue(firstName)
-	//}
+	// Remember the version of the person for the conflict check on update
+	resp.Diagnostics.Append(setPrivateVersion(ctx, resp.Private, person.Version)...)
 
-	// Save updated data into Terraform state
+	// Save updated data and identity into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setPersonIdentity(ctx, resp.Identity, personID)...)
 }
 
 func (r *PersonResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data PersonResourceModel
 
+	// Record the configured actor in the audit log
+	ctx = persondbclient.ContextWithActor(ctx, r.actor)
+
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 
@@ -206,13 +558,58 @@ func (r *PersonResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	// Bound the duration of the operation with the configured timeout
+	updateTimeout, diags := data.Timeouts.Update(ctx, r.timeouts.update)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, updateTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	personID := data.PersonID.ValueString()
-	lastName := data.LastName.ValueString()
-	firstName := data.FirstName.ValueString()
+	person, diags := personFromModel(ctx, data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only update the person when it did not change since the last refresh
+	version, diags := getPrivateVersion(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	person.Version = version
 
 	// Update person
-	err := r.client.UpdatePerson(personID, lastName, firstName)
+	updated, err := r.client.UpdatePerson(ctx, person)
+	if errors.Is(err, persondbclient.ErrPersonNotFound) {
+		// The framework does not allow Update to remove the resource from the
+		// state, the refresh of the next run does that like it does in Read
+		resp.Diagnostics.AddError(
+			"Person no longer exists",
+			"Person with person_id '"+personID+"' no longer exists in the database, it was probably deleted outside of Terraform. "+
+				"Run 'terraform apply' again to recreate it: its refresh (-refresh=true, the default) removes the person from the state "+
+				"and plans its creation, no 'terraform state rm' is needed.",
+		)
+		return
+	}
+	if errors.Is(err, persondbclient.ErrEmailAlreadyExists) {
+		addEmailAlreadyExistsError(&resp.Diagnostics, person.Email)
+		return
+	}
+	if errors.Is(err, persondbclient.ErrVersionConflict) {
+		resp.Diagnostics.AddError(
+			"Conflict updating person",
+			"Person with person_id '"+personID+"' was changed by someone else since Terraform last refreshed it, "+
+				"so applying this plan would overwrite their change. "+
+				"Run 'terraform plan' again to review the current values before applying.\n\n"+
+				"Persons DB API Client Error: "+err.Error(),
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating person",
@@ -221,13 +618,20 @@ func (r *PersonResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Save updated data into Terraform state
+	// Remember the new version of the person
+	resp.Diagnostics.Append(setPrivateVersion(ctx, resp.Private, updated.Version)...)
+
+	// Save updated data and identity into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setPersonIdentity(ctx, resp.Identity, personID)...)
 }
 
 func (r *PersonResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	var data PersonResourceModel
 
+	// Record the configured actor in the audit log
+	ctx = persondbclient.ContextWithActor(ctx, r.actor)
+
 	// Read Terraform prior state data into the model
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 
@@ -235,11 +639,29 @@ func (r *PersonResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	// Bound the duration of the operation with the configured timeout
+	deleteTimeout, diags := data.Timeouts.Delete(ctx, r.timeouts.delete)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	personID := data.PersonID.ValueString()
 
 	// Delete person
-	err := r.client.DeletePerson(personID)
+	err := r.client.DeletePerson(ctx, personID)
+	if errors.Is(err, persondbclient.ErrPersonNotFound) {
+		// The person is already gone, which is the desired end state
+		resp.Diagnostics.AddWarning(
+			"Person already deleted",
+			"Person with person_id '"+personID+"' was not found in the database, it was probably deleted outside of Terraform. "+
+				"It has been removed from the Terraform state.",
+		)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error deleting person",
@@ -250,5 +672,235 @@ func (r *PersonResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *PersonResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Import with the ID given on the command line or in an import block,
+	// otherwise with the identity given in an import block
+	attributePath := path.Root("id")
+	personID := req.ID
+	if req.ID != "" {
+		var err error
+		personID, err = parsePersonID(req.ID)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				attributePath,
+				"Invalid import ID",
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		var identity PersonResourceIdentityModel
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		attributePath = path.Root("person_id")
+		personID = identity.PersonID.ValueString()
+	}
+
+	// Check if the person exists, so a wrong ID fails the import instead of
+	// importing a person that is removed again by the next refresh
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.read)
+	defer cancel()
+	exists, err := r.client.CheckPersonExists(ctx, personID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing person",
+			"Could not check if person with person_id '"+personID+"' exists, unexpected error: "+err.Error(),
+		)
+		return
+	}
+	if !exists {
+		resp.Diagnostics.AddAttributeError(
+			attributePath,
+			"Person not found",
+			"Person with person_id '"+personID+"' does not exist in the database, so it cannot be imported.",
+		)
+		return
+	}
+
+	// Save the canonical ID, the person is read by the following refresh
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), formatPersonID(personID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("person_id"), personID)...)
+}
+
+// personFromModel returns the person described by the resource data.
+func personFromModel(ctx context.Context, data PersonResourceModel) (persondbclient.Person, diag.Diagnostics) {
+	person := persondbclient.Person{
+		PersonID:  data.PersonID.ValueString(),
+		Email:     data.Email.ValueString(),
+		BirthDate: data.BirthDate.ValueString(),
+	}
+	diags := setPersonName(ctx, &person, data.Name)
+	diags.Append(data.PhoneNumbers.ElementsAs(ctx, &person.PhoneNumbers, false)...)
+	diags.Append(data.TagsAll.ElementsAs(ctx, &person.Tags, false)...)
+	var addresses []PersonAddressModel
+	diags.Append(data.Addresses.ElementsAs(ctx, &addresses, false)...)
+	for _, address := range addresses {
+		person.Addresses = append(person.Addresses, persondbclient.Address{
+			Type:       address.Type.ValueString(),
+			Street:     address.Street.ValueString(),
+			City:       address.City.ValueString(),
+			PostalCode: address.PostalCode.ValueString(),
+			Country:    address.Country.ValueString(),
+		})
+	}
+	return person, diags
+}
+
+// setPersonModel sets the resource data to the values of the stored person.
+// The stored tags include the provider default tags, which tags they are is
+// decided from the prior state, see resourceTags.
+func setPersonModel(ctx context.Context, data *PersonResourceModel, person *persondbclient.Person, defaultTags map[string]string) diag.Diagnostics {
+	data.PersonID = types.StringValue(person.PersonID)
+	data.Name = nameValue(person)
+	data.DisplayName = types.StringValue(displayName(person))
+	data.Email = types.StringValue(person.Email)
+	data.BirthDate = types.StringValue(person.BirthDate)
+	phoneNumbers, diags := types.SetValueFrom(ctx, types.StringType, emptyIfNil(person.PhoneNumbers))
+	data.PhoneNumbers = phoneNumbers
+	tags, tagsDiags := tagsValue(ctx, resourceTags(person.Tags, data.Tags, data.TagsAll, defaultTags))
+	diags.Append(tagsDiags...)
+	data.Tags = tags
+	tagsAll, tagsDiags := tagsValue(ctx, person.Tags)
+	diags.Append(tagsDiags...)
+	data.TagsAll = tagsAll
+	addresses, addressesDiags := addressesValue(ctx, person.Addresses)
+	diags.Append(addressesDiags...)
+	data.Addresses = addresses
+	return diags
+}
+
+// addressesValue returns the addresses as a list value, empty instead of
+// null when the person has no addresses.
+func addressesValue(ctx context.Context, addresses []persondbclient.Address) (types.List, diag.Diagnostics) {
+	models := make([]PersonAddressModel, 0, len(addresses))
+	for _, address := range addresses {
+		models = append(models, PersonAddressModel{
+			Type:       types.StringValue(address.Type),
+			Street:     types.StringValue(address.Street),
+			City:       types.StringValue(address.City),
+			PostalCode: types.StringValue(address.PostalCode),
+			Country:    types.StringValue(address.Country),
+		})
+	}
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: personAddressAttrTypes}, models)
+}
+
+// resourceTags returns the stored tags that belong in tags: the ones in the
+// prior tags, and the ones missing from the prior tags_all, which were added
+// outside of Terraform. The other stored tags came from the provider default
+// tags, also when the provider no longer sets them. Without prior tags_all,
+// as on import, the stored tags with the key of a provider default tag are
+// left out.
+func resourceTags(storedTags map[string]string, priorTags, priorTagsAll types.Map, defaultTags map[string]string) map[string]string {
+	tags := map[string]string{}
+	for key, value := range storedTags {
+		_, inPriorTags := priorTags.Elements()[key]
+		if priorTagsAll.IsNull() || priorTagsAll.IsUnknown() {
+			if _, isDefault := defaultTags[key]; !isDefault || inPriorTags {
+				tags[key] = value
+			}
+			continue
+		}
+		if _, inPriorTagsAll := priorTagsAll.Elements()[key]; inPriorTags || !inPriorTagsAll {
+			tags[key] = value
+		}
+	}
+	return tags
+}
+
+// mergeTags returns the default tags overridden by the resource tags. The
+// result is unknown when the resource tags are unknown.
+func mergeTags(defaultTags map[string]string, tags types.Map) (types.Map, diag.Diagnostics) {
+	if tags.IsUnknown() {
+		return types.MapUnknown(types.StringType), nil
+	}
+	merged := make(map[string]attr.Value, len(defaultTags)+len(tags.Elements()))
+	for key, value := range defaultTags {
+		merged[key] = types.StringValue(value)
+	}
+	for key, value := range tags.Elements() {
+		merged[key] = value
+	}
+	return types.MapValue(types.StringType, merged)
+}
+
+// emptyIfNil returns an empty slice for nil, so it converts to an empty set
+// instead of a null set.
+func emptyIfNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// tagsValue returns the tags as a map value, empty instead of null when the
+// person has no tags.
+func tagsValue(ctx context.Context, tags map[string]string) (types.Map, diag.Diagnostics) {
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	return types.MapValueFrom(ctx, types.StringType, tags)
+}
+
+// addEmailAlreadyExistsError reports an email address used by another person.
+func addEmailAlreadyExistsError(diags *diag.Diagnostics, email string) {
+	diags.AddAttributeError(
+		path.Root("email"),
+		"Email already in use",
+		"Email '"+email+"' is already used by another person in the database, email addresses are unique compared case-insensitively.",
+	)
+}
+
+// nullTimeouts returns the value of a timeouts block that is not configured.
+func nullTimeouts() timeouts.Value {
+	return timeouts.Value{
+		Object: types.ObjectNull(map[string]attr.Type{
+			"create": types.StringType,
+			"read":   types.StringType,
+			"update": types.StringType,
+			"delete": types.StringType,
+		}),
+	}
+}
+
+// setPersonIdentity stores the identity of the person. The identity is nil
+// when Terraform does not support resource identities.
+func setPersonIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, personID string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, PersonResourceIdentityModel{
+		PersonID: types.StringValue(personID),
+	})
+}
+
+// privateVersionKey is the private state key holding the version of the
+// person as last seen by Terraform.
+const privateVersionKey = "version"
+
+// getPrivateVersion returns the person version stored in private state, or 0
+// when none is stored yet, which disables the conflict check.
+func getPrivateVersion(ctx context.Context, private interface {
+	GetKey(context.Context, string) ([]byte, diag.Diagnostics)
+}) (int64, diag.Diagnostics) {
+	value, diags := private.GetKey(ctx, privateVersionKey)
+	if diags.HasError() || len(value) == 0 {
+		return 0, diags
+	}
+	version, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		diags.AddError(
+			"Invalid private state",
+			"Could not parse the person version stored in private state: "+err.Error(),
+		)
+	}
+	return version, diags
+}
+
+// setPrivateVersion stores the person version in private state.
+func setPrivateVersion(ctx context.Context, private interface {
+	SetKey(context.Context, string, []byte) diag.Diagnostics
+}, version int64) diag.Diagnostics {
+	return private.SetKey(ctx, privateVersionKey, []byte(strconv.FormatInt(version, 10)))
 }
@@ -0,0 +1,246 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// newTestPersonResource returns a person resource configured with an empty
+// in-memory store.
+func newTestPersonResource() *PersonResource {
+	return &PersonResource{
+		client: persondbclient.NewMemoryStore(persondbclient.Options{}),
+		timeouts: persondbTimeouts{
+			create: defaultTimeout,
+			read:   defaultTimeout,
+			update: defaultTimeout,
+			delete: defaultTimeout,
+		},
+	}
+}
+
+// testPersonResourceData returns the plan or state data of a person.
+func testPersonResourceData(t *testing.T, r *PersonResource, personID string) (tfsdk.Plan, tfsdk.State) {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	data := PersonResourceModel{
+		ID:       types.StringValue(formatPersonID(personID)),
+		Timeouts: nullTimeouts(),
+	}
+	diags := setPersonModel(ctx, &data, &persondbclient.Person{PersonID: personID, LastName: "Peeters", FirstName: "Jan"}, nil)
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	diags.Append(plan.Set(ctx, &data)...)
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	diags.Append(state.Set(ctx, &data)...)
+	if diags.HasError() {
+		t.Fatalf("building resource data: %v", diags)
+	}
+	return plan, state
+}
+
+func TestPersonResourceUpdateDeletedOutOfBandRecoversOnRefresh(t *testing.T) {
+	ctx := context.Background()
+	r := newTestPersonResource()
+	plan, state := testPersonResourceData(t, r, "1")
+
+	updateResp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, &updateResp)
+
+	if !updateResp.Diagnostics.HasError() {
+		t.Fatal("Update: expected an error")
+	}
+	detail := updateResp.Diagnostics.Errors()[0].Detail()
+	if !strings.Contains(detail, "Run 'terraform apply' again to recreate it") || !strings.Contains(detail, "-refresh=true") {
+		t.Errorf("Update: error detail does not explain that the refresh of the next run recovers: %s", detail)
+	}
+
+	// The refresh of the next run removes the person from the state
+	readResp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &readResp)
+	if readResp.Diagnostics.HasError() {
+		t.Fatalf("Read: unexpected error: %v", readResp.Diagnostics)
+	}
+	if !readResp.State.Raw.IsNull() {
+		t.Error("Read: expected the person to be removed from the state")
+	}
+}
+
+func TestPersonResourceModifyPlanUnconfigured(t *testing.T) {
+	ctx := context.Background()
+	r := &PersonResource{}
+	plan, _ := testPersonResourceData(t, r, "1")
+	diags := plan.SetAttribute(ctx, path.Root("display_name"), types.StringUnknown())
+	diags.Append(plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+	diags.Append(plan.SetAttribute(ctx, path.Root("name").AtName("given"), types.StringValue(strings.Repeat("J", defaultMaxNameLength+1)))...)
+	if diags.HasError() {
+		t.Fatalf("building plan: %v", diags)
+	}
+
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: tfsdk.State{Raw: tftypes.NewValue(plan.Schema.Type().TerraformType(ctx), nil), Schema: plan.Schema}}, &resp)
+
+	errs := resp.Diagnostics.Errors()
+	if len(errs) != 1 || errs[0].Summary() != "Name too long" {
+		t.Errorf("ModifyPlan: got errors %v, want one \"Name too long\" error", errs)
+	}
+	var displayName types.String
+	resp.Plan.GetAttribute(ctx, path.Root("display_name"), &displayName)
+	if displayName.IsUnknown() {
+		t.Error("ModifyPlan: display_name is not planned")
+	}
+	var tagsAll types.Map
+	resp.Plan.GetAttribute(ctx, path.Root("tags_all"), &tagsAll)
+	if tagsAll.IsUnknown() {
+		t.Error("ModifyPlan: tags_all is not planned")
+	}
+}
+
+func TestPersonResourceDeleteAbsent(t *testing.T) {
+	ctx := context.Background()
+	r := newTestPersonResource()
+	_, state := testPersonResourceData(t, r, "1")
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Delete: unexpected error: %v", resp.Diagnostics)
+	}
+	warnings := resp.Diagnostics.Warnings()
+	if len(warnings) != 1 || warnings[0].Summary() != "Person already deleted" {
+		t.Errorf("Delete: got warnings %v, want one \"Person already deleted\" warning", warnings)
+	}
+}
+
+func TestResourceTags(t *testing.T) {
+	tagsMap := func(tags map[string]string) types.Map {
+		value, diags := tagsValue(context.Background(), tags)
+		if diags.HasError() {
+			t.Fatalf("tagsValue: %v", diags)
+		}
+		return value
+	}
+
+	testCases := map[string]struct {
+		storedTags   map[string]string
+		priorTags    types.Map
+		priorTagsAll types.Map
+		defaultTags  map[string]string
+		want         map[string]string
+	}{
+		"default tag": {
+			storedTags:   map[string]string{"team": "platform", "owner": "terraform"},
+			priorTags:    tagsMap(map[string]string{"team": "platform"}),
+			priorTagsAll: tagsMap(map[string]string{"team": "platform", "owner": "terraform"}),
+			defaultTags:  map[string]string{"owner": "terraform"},
+			want:         map[string]string{"team": "platform"},
+		},
+		"default tag removed from the provider": {
+			storedTags:   map[string]string{"team": "platform", "owner": "terraform"},
+			priorTags:    tagsMap(map[string]string{"team": "platform"}),
+			priorTagsAll: tagsMap(map[string]string{"team": "platform", "owner": "terraform"}),
+			defaultTags:  nil,
+			want:         map[string]string{"team": "platform"},
+		},
+		"default tag overridden": {
+			storedTags:   map[string]string{"owner": "platform"},
+			priorTags:    tagsMap(map[string]string{"owner": "platform"}),
+			priorTagsAll: tagsMap(map[string]string{"owner": "platform"}),
+			defaultTags:  map[string]string{"owner": "terraform"},
+			want:         map[string]string{"owner": "platform"},
+		},
+		"tag added outside of Terraform": {
+			storedTags:   map[string]string{"team": "platform", "location": "gent"},
+			priorTags:    tagsMap(map[string]string{"team": "platform"}),
+			priorTagsAll: tagsMap(map[string]string{"team": "platform"}),
+			defaultTags:  map[string]string{"location": "antwerpen"},
+			want:         map[string]string{"team": "platform", "location": "gent"},
+		},
+		"import": {
+			storedTags:   map[string]string{"team": "platform", "owner": "terraform"},
+			priorTags:    types.MapNull(types.StringType),
+			priorTagsAll: types.MapNull(types.StringType),
+			defaultTags:  map[string]string{"owner": "terraform"},
+			want:         map[string]string{"team": "platform"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got := resourceTags(testCase.storedTags, testCase.priorTags, testCase.priorTagsAll, testCase.defaultTags)
+			if !maps.Equal(got, testCase.want) {
+				t.Errorf("got %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	tagsMap := func(tags map[string]string) types.Map {
+		value, diags := tagsValue(context.Background(), tags)
+		if diags.HasError() {
+			t.Fatalf("tagsValue: %v", diags)
+		}
+		return value
+	}
+
+	testCases := map[string]struct {
+		defaultTags map[string]string
+		tags        types.Map
+		want        types.Map
+	}{
+		"no default tags": {
+			tags: tagsMap(map[string]string{"team": "platform"}),
+			want: tagsMap(map[string]string{"team": "platform"}),
+		},
+		"default tags only": {
+			defaultTags: map[string]string{"owner": "terraform"},
+			tags:        types.MapNull(types.StringType),
+			want:        tagsMap(map[string]string{"owner": "terraform"}),
+		},
+		"default and resource tags": {
+			defaultTags: map[string]string{"owner": "terraform"},
+			tags:        tagsMap(map[string]string{"team": "platform"}),
+			want:        tagsMap(map[string]string{"owner": "terraform", "team": "platform"}),
+		},
+		"resource tag overrides default tag": {
+			defaultTags: map[string]string{"owner": "terraform", "location": "gent"},
+			tags:        tagsMap(map[string]string{"owner": "platform"}),
+			want:        tagsMap(map[string]string{"owner": "platform", "location": "gent"}),
+		},
+		"no tags": {
+			tags: types.MapNull(types.StringType),
+			want: tagsMap(nil),
+		},
+		"unknown tags": {
+			defaultTags: map[string]string{"owner": "terraform"},
+			tags:        types.MapUnknown(types.StringType),
+			want:        types.MapUnknown(types.StringType),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, diags := mergeTags(testCase.defaultTags, testCase.tags)
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if !got.Equal(testCase.want) {
+				t.Errorf("got %v, want %v", got, testCase.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.ResourceWithUpgradeState = &PersonResource{}
+
+// personResourceSchemaVersion is the current version of the persondb_person
+// schema. Bump it together with an upgrader in UpgradeState whenever the
+// schema or the ID format changes.
+const personResourceSchemaVersion = 2
+
+// UpgradeState upgrades the state of older persondb_person schema versions to
+// the current version.
+func (r *PersonResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// Version 0 did not validate the ID, version 1 always stores the
+		// canonical "/person/<person_id>" ID.
+		0: {
+			PriorSchema:   personResourceSchemaV0(),
+			StateUpgrader: upgradePersonResourceStateV0,
+		},
+		// Version 1 stored the name in last_name and first_name, version 2
+		// stores it in the name attribute.
+		1: {
+			PriorSchema:   personResourceSchemaV1(ctx),
+			StateUpgrader: upgradePersonResourceStateV1,
+		},
+	}
+}
+
+// personResourceModelV0 maps the version 0 schema data.
+type personResourceModelV0 struct {
+	ID        types.String `tfsdk:"id"`
+	PersonID  types.String `tfsdk:"person_id"`
+	LastName  types.String `tfsdk:"last_name"`
+	FirstName types.String `tfsdk:"first_name"`
+}
+
+// personResourceSchemaV0 returns the version 0 schema, only the attribute
+// types matter to read the prior state.
+func personResourceSchemaV0() *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"person_id": schema.StringAttribute{
+				Required: true,
+			},
+			"last_name": schema.StringAttribute{
+				Required: true,
+			},
+			"first_name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// upgradePersonResourceStateV0 normalises the ID of version 0 state to the
+// canonical ID and makes it consistent with person_id.
+func upgradePersonResourceStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior personResourceModelV0
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// person_id is the source of truth, the ID is only used when person_id
+	// is missing from the state
+	personID := prior.PersonID.ValueString()
+	if personID == "" {
+		var err error
+		personID, err = parsePersonID(prior.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"Unable to upgrade state",
+				"Could not determine the person_id of the resource: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	name, displayName := upgradedName(prior.LastName, prior.FirstName)
+	data := PersonResourceModel{
+		ID:           types.StringValue(formatPersonID(personID)),
+		PersonID:     types.StringValue(personID),
+		Name:         name,
+		DisplayName:  displayName,
+		Email:        types.StringValue(""),
+		BirthDate:    types.StringValue(""),
+		PhoneNumbers: types.SetValueMust(types.StringType, []attr.Value{}),
+		Tags:         types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		TagsAll:      types.MapValueMust(types.StringType, map[string]attr.Value{}),
+		Addresses:    types.ListValueMust(types.ObjectType{AttrTypes: personAddressAttrTypes}, []attr.Value{}),
+		Timeouts:     nullTimeouts(),
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// personResourceModelV1 maps the version 1 schema data.
+type personResourceModelV1 struct {
+	ID           types.String   `tfsdk:"id"`
+	PersonID     types.String   `tfsdk:"person_id"`
+	LastName     types.String   `tfsdk:"last_name"`
+	FirstName    types.String   `tfsdk:"first_name"`
+	Email        types.String   `tfsdk:"email"`
+	BirthDate    types.String   `tfsdk:"birth_date"`
+	PhoneNumbers types.Set      `tfsdk:"phone_numbers"`
+	Tags         types.Map      `tfsdk:"tags"`
+	TagsAll      types.Map      `tfsdk:"tags_all"`
+	Addresses    types.List     `tfsdk:"address"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// personResourceSchemaV1 returns the version 1 schema, only the attribute
+// types matter to read the prior state.
+func personResourceSchemaV1(ctx context.Context) *schema.Schema {
+	return &schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"person_id": schema.StringAttribute{
+				Required: true,
+			},
+			"last_name": schema.StringAttribute{
+				Required: true,
+			},
+			"first_name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"email": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"birth_date": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"phone_numbers": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+			},
+			"tags_all": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"address": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Required: true,
+						},
+						"street": schema.StringAttribute{
+							Required: true,
+						},
+						"city": schema.StringAttribute{
+							Required: true,
+						},
+						"postal_code": schema.StringAttribute{
+							Optional: true,
+							Computed: true,
+						},
+						"country": schema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
+			},
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// upgradePersonResourceStateV1 moves last_name and first_name of version 1
+// state into the name attribute.
+func upgradePersonResourceStateV1(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior personResourceModelV1
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, displayName := upgradedName(prior.LastName, prior.FirstName)
+	data := PersonResourceModel{
+		ID:           prior.ID,
+		PersonID:     prior.PersonID,
+		Name:         name,
+		DisplayName:  displayName,
+		Email:        prior.Email,
+		BirthDate:    prior.BirthDate,
+		PhoneNumbers: prior.PhoneNumbers,
+		Tags:         prior.Tags,
+		TagsAll:      prior.TagsAll,
+		Addresses:    prior.Addresses,
+		Timeouts:     prior.Timeouts,
+	}
+
+	// Version 1 state written before the contact details, tags and addresses
+	// were added lacks them, they are empty like the schema defaults
+	if data.Email.IsNull() {
+		data.Email = types.StringValue("")
+	}
+	if data.BirthDate.IsNull() {
+		data.BirthDate = types.StringValue("")
+	}
+	if data.PhoneNumbers.IsNull() {
+		data.PhoneNumbers = types.SetValueMust(types.StringType, []attr.Value{})
+	}
+	if data.Tags.IsNull() {
+		data.Tags = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+	if data.TagsAll.IsNull() {
+		data.TagsAll = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	}
+	if data.Addresses.IsNull() {
+		data.Addresses = types.ListValueMust(types.ObjectType{AttrTypes: personAddressAttrTypes}, []attr.Value{})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// upgradedName returns the name and display name of a person stored with a
+// last name and a first name, which are the family and the given name.
+func upgradedName(lastName, firstName types.String) (types.Object, types.String) {
+	person := &persondbclient.Person{
+		LastName:  lastName.ValueString(),
+		FirstName: firstName.ValueString(),
+	}
+	return nameValue(person), types.StringValue(displayName(person))
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// upgradeTestState runs the upgrader of the schema version on the raw state
+// JSON, the way Terraform sends state written by an older provider.
+func upgradeTestState(t *testing.T, version int64, rawState string) PersonResourceModel {
+	t.Helper()
+	ctx := context.Background()
+	r := &PersonResource{}
+
+	upgrader, ok := r.UpgradeState(ctx)[version]
+	if !ok {
+		t.Fatalf("no upgrader for schema version %d", version)
+	}
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+	raw, err := (&tfprotov6.RawState{JSON: []byte(rawState)}).UnmarshalWithOpts(priorType, tfprotov6.UnmarshalOpts{})
+	if err != nil {
+		t.Fatalf("unmarshalling raw state: %v", err)
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	req := resource.UpgradeStateRequest{
+		State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: raw},
+	}
+	resp := resource.UpgradeStateResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)},
+	}
+	upgrader.StateUpgrader(ctx, req, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("upgrading state: %v", resp.Diagnostics)
+	}
+
+	var data PersonResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("reading upgraded state: %v", diags)
+	}
+	return data
+}
+
+// checkUpgradedName checks the name and display name of upgraded state.
+func checkUpgradedName(t *testing.T, data PersonResourceModel, given, family, display string) {
+	t.Helper()
+	attributes := data.Name.Attributes()
+	if attributes["given"] != types.StringValue(given) || attributes["family"] != types.StringValue(family) {
+		t.Errorf("name: got %v, want given %q and family %q", data.Name, given, family)
+	}
+	for _, part := range []string{"prefix", "middle", "suffix", "preferred"} {
+		if attributes[part] != types.StringValue("") {
+			t.Errorf("name.%s: got %v, want an empty string", part, attributes[part])
+		}
+	}
+	if data.DisplayName != types.StringValue(display) {
+		t.Errorf("display_name: got %v, want %q", data.DisplayName, display)
+	}
+}
+
+// checkUpgradedDefaults checks that the attributes missing from older state
+// are empty, as the schema defaults would set them.
+func checkUpgradedDefaults(t *testing.T, data PersonResourceModel) {
+	t.Helper()
+	emptyStrings := map[string]types.String{
+		"email":      data.Email,
+		"birth_date": data.BirthDate,
+	}
+	for name, value := range emptyStrings {
+		if value != types.StringValue("") {
+			t.Errorf("%s: got %v, want an empty string", name, value)
+		}
+	}
+	emptyCollections := map[string]interface {
+		IsNull() bool
+		IsUnknown() bool
+		Elements() []attr.Value
+	}{
+		"phone_numbers": data.PhoneNumbers,
+		"address":       data.Addresses,
+	}
+	for name, value := range emptyCollections {
+		if value.IsNull() || value.IsUnknown() || len(value.Elements()) != 0 {
+			t.Errorf("%s: got %v, want an empty collection", name, value)
+		}
+	}
+	for name, value := range map[string]types.Map{"tags": data.Tags, "tags_all": data.TagsAll} {
+		if value.IsNull() || value.IsUnknown() || len(value.Elements()) != 0 {
+			t.Errorf("%s: got %v, want an empty map", name, value)
+		}
+	}
+	if !data.Timeouts.IsNull() {
+		t.Errorf("timeouts: got %v, want null", data.Timeouts)
+	}
+}
+
+func TestUpgradePersonResourceStateV0(t *testing.T) {
+	testCases := map[string]struct {
+		rawState        string
+		wantID          string
+		wantPersonID    string
+		wantGiven       string
+		wantFamily      string
+		wantDisplayName string
+	}{
+		"person_id from ID": {
+			rawState:        `{"id": "/person/1", "person_id": "", "last_name": "Van den Wyngaert", "first_name": "Wim"}`,
+			wantID:          "/person/1",
+			wantPersonID:    "1",
+			wantGiven:       "Wim",
+			wantFamily:      "Van den Wyngaert",
+			wantDisplayName: "Wim Van den Wyngaert",
+		},
+		"plain ID": {
+			rawState:        `{"id": "2", "person_id": "2", "last_name": "Peeters", "first_name": "Jan"}`,
+			wantID:          "/person/2",
+			wantPersonID:    "2",
+			wantGiven:       "Jan",
+			wantFamily:      "Peeters",
+			wantDisplayName: "Jan Peeters",
+		},
+		"null first_name": {
+			rawState:        `{"id": "/person/3", "person_id": "3", "last_name": "Janssens", "first_name": null}`,
+			wantID:          "/person/3",
+			wantPersonID:    "3",
+			wantGiven:       "",
+			wantFamily:      "Janssens",
+			wantDisplayName: "Janssens",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			data := upgradeTestState(t, 0, testCase.rawState)
+
+			if data.ID != types.StringValue(testCase.wantID) {
+				t.Errorf("id: got %v, want %q", data.ID, testCase.wantID)
+			}
+			if data.PersonID != types.StringValue(testCase.wantPersonID) {
+				t.Errorf("person_id: got %v, want %q", data.PersonID, testCase.wantPersonID)
+			}
+			checkUpgradedName(t, data, testCase.wantGiven, testCase.wantFamily, testCase.wantDisplayName)
+			checkUpgradedDefaults(t, data)
+		})
+	}
+}
+
+func TestUpgradePersonResourceStateV1(t *testing.T) {
+	t.Run("before contact details", func(t *testing.T) {
+		data := upgradeTestState(t, 1, `{"id": "/person/1", "person_id": "1", "last_name": "Van den Wyngaert", "first_name": "Wim"}`)
+
+		if data.ID != types.StringValue("/person/1") || data.PersonID != types.StringValue("1") {
+			t.Errorf("id and person_id: got %v and %v, want \"/person/1\" and \"1\"", data.ID, data.PersonID)
+		}
+		checkUpgradedName(t, data, "Wim", "Van den Wyngaert", "Wim Van den Wyngaert")
+		checkUpgradedDefaults(t, data)
+	})
+
+	t.Run("all attributes", func(t *testing.T) {
+		data := upgradeTestState(t, 1, `{
+			"id": "/person/2",
+			"person_id": "2",
+			"last_name": "Peeters",
+			"first_name": "",
+			"email": "jan.peeters@example.com",
+			"birth_date": "1990-12-31",
+			"phone_numbers": ["+32 470 12 34 56"],
+			"tags": {"team": "platform"},
+			"tags_all": {"team": "platform", "owner": "terraform"},
+			"address": [{"type": "home", "street": "Kerkstraat 1", "city": "Antwerpen", "postal_code": "2000", "country": "BE"}],
+			"timeouts": {"create": "5m", "read": null, "update": null, "delete": null}
+		}`)
+
+		checkUpgradedName(t, data, "", "Peeters", "Peeters")
+		if data.Email != types.StringValue("jan.peeters@example.com") || data.BirthDate != types.StringValue("1990-12-31") {
+			t.Errorf("email and birth_date: got %v and %v", data.Email, data.BirthDate)
+		}
+		if len(data.PhoneNumbers.Elements()) != 1 || len(data.Addresses.Elements()) != 1 {
+			t.Errorf("phone_numbers and address: got %v and %v, want one element each", data.PhoneNumbers, data.Addresses)
+		}
+		if len(data.Tags.Elements()) != 1 || len(data.TagsAll.Elements()) != 2 {
+			t.Errorf("tags and tags_all: got %v and %v", data.Tags, data.TagsAll)
+		}
+		createTimeout, diags := data.Timeouts.Create(context.Background(), defaultTimeout)
+		if diags.HasError() || createTimeout.String() != "5m0s" {
+			t.Errorf("timeouts.create: got %v, want 5m0s", createTimeout)
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &PersonsDataSource{}
+	_ datasource.DataSourceWithConfigure = &PersonsDataSource{}
+)
+
+// Orderings of the persons data source, named after the parts of the name of
+// a person.
+const (
+	orderByPersonID   = "person_id"
+	orderByFamilyName = "family_name"
+	orderByGivenName  = "given_name"
+)
+
+// clientOrderBy maps the orderings of the data source to the orderings of
+// the client, which are named after the stored columns.
+var clientOrderBy = map[string]string{
+	orderByPersonID:   persondbclient.OrderByPersonID,
+	orderByFamilyName: persondbclient.OrderByLastName,
+	orderByGivenName:  persondbclient.OrderByFirstName,
+}
+
+// NewPersonsDataSource is a helper function to simplify the provider implementation.
+func NewPersonsDataSource() datasource.DataSource {
+	return &PersonsDataSource{}
+}
+
+// PersonsDataSource is the data source implementation.
+type PersonsDataSource struct {
+	client persondbclient.PersonStore
+}
+
+// PersonsDataSourceModel maps the data source schema data.
+type PersonsDataSourceModel struct {
+	ID               types.String         `tfsdk:"id"`
+	FamilyNamePrefix types.String         `tfsdk:"family_name_prefix"`
+	GivenName        types.String         `tfsdk:"given_name"`
+	PersonIDs        []types.String       `tfsdk:"person_ids"`
+	Tags             map[string]string    `tfsdk:"tags"`
+	OrderBy          types.String         `tfsdk:"order_by"`
+	Descending       types.Bool           `tfsdk:"descending"`
+	Limit            types.Int64          `tfsdk:"limit"`
+	Offset           types.Int64          `tfsdk:"offset"`
+	Persons          []PersonsPersonModel `tfsdk:"persons"`
+}
+
+// PersonsPersonModel maps one person of the persons list.
+type PersonsPersonModel struct {
+	ID          types.String `tfsdk:"id"`
+	PersonID    types.String `tfsdk:"person_id"`
+	Name        types.Object `tfsdk:"name"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Tags        types.Map    `tfsdk:"tags"`
+}
+
+// Metadata returns the data source type name.
+func (d *PersonsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_persons"
+}
+
+// Schema defines the schema for the data source.
+func (d *PersonsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the persons in the database matching all the given filters.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"family_name_prefix": schema.StringAttribute{
+				Description: "Only list persons whose family name starts with this prefix, case-sensitively.",
+				Optional:    true,
+			},
+			"given_name": schema.StringAttribute{
+				Description: "Only list persons with exactly this given name.",
+				Optional:    true,
+			},
+			"person_ids": schema.ListAttribute{
+				Description: "Only list persons with one of these person IDs.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Only list persons having all of these tags with the same values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"order_by": schema.StringAttribute{
+				Description: "Attribute to order the persons by: \"person_id\", \"family_name\" or \"given_name\". Defaults to \"person_id\".",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(orderByPersonID, orderByFamilyName, orderByGivenName),
+				},
+			},
+			"descending": schema.BoolAttribute{
+				Description: "Order the persons in descending order. Defaults to false.",
+				Optional:    true,
+			},
+			"limit": schema.Int64Attribute{
+				Description: "Maximum number of persons to list. Defaults to no limit.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"offset": schema.Int64Attribute{
+				Description: "Number of persons to skip, for pagination with limit. Defaults to 0.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"persons": schema.ListNestedAttribute{
+				Description: "Persons matching the filters.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "ID of the person, as used by the persondb_person resource.",
+							Computed:    true,
+						},
+						"person_id": schema.StringAttribute{
+							Description: "Person ID in the database.",
+							Computed:    true,
+						},
+						"name":         nameDataSourceAttribute(),
+						"display_name": displayNameDataSourceAttribute(),
+						"tags": schema.MapAttribute{
+							Description: "Tags of the person.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *PersonsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data PersonsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := persondbclient.ListFilter{
+		LastNamePrefix: data.FamilyNamePrefix.ValueString(),
+		FirstName:      data.GivenName.ValueString(),
+		Tags:           data.Tags,
+		OrderBy:        clientOrderBy[data.OrderBy.ValueString()],
+		Descending:     data.Descending.ValueBool(),
+		Limit:          int(data.Limit.ValueInt64()),
+		Offset:         int(data.Offset.ValueInt64()),
+	}
+	for _, personID := range data.PersonIDs {
+		filter.PersonIDs = append(filter.PersonIDs, personID.ValueString())
+	}
+
+	persons, err := d.client.ListPersons(ctx, filter)
+	if errors.Is(err, persondbclient.ErrInvalidFilter) {
+		resp.Diagnostics.AddError(
+			"Invalid persons filter",
+			err.Error(),
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing persons",
+			"Could not list persons, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	data.ID = types.StringValue("/persons")
+	data.Persons = make([]PersonsPersonModel, 0, len(persons))
+	for _, person := range persons {
+		tags, diags := tagsValue(ctx, person.Tags)
+		resp.Diagnostics.Append(diags...)
+		data.Persons = append(data.Persons, PersonsPersonModel{
+			ID:          types.StringValue(formatPersonID(person.PersonID)),
+			PersonID:    types.StringValue(person.PersonID),
+			Name:        nameValue(&person),
+			DisplayName: types.StringValue(displayName(&person)),
+			Tags:        tags,
+		})
+	}
+
+	// Set data
+	diags := resp.State.Set(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *PersonsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*persondbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *persondbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.client
+}
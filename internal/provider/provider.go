@@ -2,20 +2,34 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"os"
+	"os/user"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &persondbProvider{}
+	_ provider.Provider                  = &persondbProvider{}
+	_ provider.ProviderWithListResources = &persondbProvider{}
+	_ provider.ProviderWithFunctions     = &persondbProvider{}
+	_ provider.ProviderWithActions       = &persondbProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -27,9 +41,104 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// Storage backends supported by the provider.
+const (
+	backendSQLite = "sqlite"
+	backendMemory = "memory"
+	backendJSON   = "json"
+	backendHTTP   = "http"
+)
+
+// Severities of the plan time check for persons that already exist.
+const (
+	existingPersonCheckError   = "error"
+	existingPersonCheckWarning = "warning"
+)
+
 // persondbProviderModel maps provider schema data to a Go type.
 type persondbProviderModel struct {
-	Database types.String `tfsdk:"database_filename"`
+	Database              types.String              `tfsdk:"database_filename"`
+	MaxOpenConnections    types.Int64               `tfsdk:"max_open_connections"`
+	MaxIdleConnections    types.Int64               `tfsdk:"max_idle_connections"`
+	ConnectionMaxLifetime types.String              `tfsdk:"connection_max_lifetime"`
+	BusyTimeout           types.String              `tfsdk:"busy_timeout"`
+	JournalMode           types.String              `tfsdk:"journal_mode"`
+	ForeignKeys           types.Bool                `tfsdk:"foreign_keys"`
+	MaxRetries            types.Int64               `tfsdk:"max_retries"`
+	RetryMaxWait          types.String              `tfsdk:"retry_max_wait"`
+	Actor                 types.String              `tfsdk:"actor"`
+	SoftDelete            types.Bool                `tfsdk:"soft_delete"`
+	ExistingPersonCheck   types.String              `tfsdk:"existing_person_check"`
+	AdoptExisting         types.Bool                `tfsdk:"adopt_existing"`
+	MaxNameLength         types.Int64               `tfsdk:"max_name_length"`
+	Backend               *persondbBackendModel     `tfsdk:"backend"`
+	DefaultTimeouts       *persondbTimeoutsModel    `tfsdk:"default_timeouts"`
+	DefaultTags           *persondbDefaultTagsModel `tfsdk:"default_tags"`
+}
+
+// persondbProviderData is made available to resources and data sources by
+// Configure.
+type persondbProviderData struct {
+	client persondbclient.PersonStore
+
+	// actor is recorded in the audit log as the author of every change.
+	actor string
+
+	// existingPersonCheck is the severity of the diagnostic reported at plan
+	// time when a person planned for creation already exists.
+	existingPersonCheck string
+
+	// adoptExisting makes creating a person that already exists take it over
+	// instead of failing.
+	adoptExisting bool
+
+	// timeouts are the default persondb_person operation timeouts, used when
+	// the timeouts block of the resource does not set them.
+	timeouts persondbTimeouts
+
+	// defaultTags are merged into the tags of every person.
+	defaultTags map[string]string
+
+	// maxNameLength is the maximum number of characters of each name part.
+	maxNameLength int64
+}
+
+// persondbTimeouts holds the default timeouts of the resource operations.
+type persondbTimeouts struct {
+	create time.Duration
+	read   time.Duration
+	update time.Duration
+	delete time.Duration
+}
+
+// defaultTimeout is the timeout of a resource operation when neither the
+// resource nor the provider sets it.
+const defaultTimeout = 20 * time.Minute
+
+// defaultMaxNameLength is the maximum number of characters of each name part
+// when max_name_length is not set.
+const defaultMaxNameLength = 100
+
+// persondbBackendModel maps the backend block schema data.
+type persondbBackendModel struct {
+	Type           types.String `tfsdk:"type"`
+	Path           types.String `tfsdk:"path"`
+	Endpoint       types.String `tfsdk:"endpoint"`
+	Token          types.String `tfsdk:"token"`
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+}
+
+// persondbTimeoutsModel maps the default_timeouts block schema data.
+type persondbTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// persondbDefaultTagsModel maps the default_tags block schema data.
+type persondbDefaultTagsModel struct {
+	Tags types.Map `tfsdk:"tags"`
 }
 
 // persondbProvider is the provider implementation.
@@ -38,6 +147,11 @@ type persondbProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// client is the store opened by the last Configure call. Terraform may
+	// configure a provider instance more than once, the previous store is
+	// closed so its connections are not leaked.
+	client persondbclient.PersonStore
 }
 
 // Metadata returns the provider type name.
@@ -54,6 +168,141 @@ func (p *persondbProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Description: "Persons Database filename. May also be provided via CUSTOM_DATABASE_FILENAME environment variable.",
 				Optional:    true,
 			},
+			"max_open_connections": schema.Int64Attribute{
+				Description: "Maximum number of open connections to the Persons Database. 0 means unlimited. Defaults to 4.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"max_idle_connections": schema.Int64Attribute{
+				Description: "Maximum number of idle connections kept open to the Persons Database. Defaults to 4.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"connection_max_lifetime": schema.StringAttribute{
+				Description: "Maximum amount of time a connection may be reused, as a duration string such as \"30m\". Defaults to no limit.",
+				Optional:    true,
+			},
+			"busy_timeout": schema.StringAttribute{
+				Description: "How long SQLite waits on a locked database before failing (PRAGMA busy_timeout), as a duration string such as \"5s\". Defaults to \"5s\".",
+				Optional:    true,
+			},
+			"journal_mode": schema.StringAttribute{
+				Description: "SQLite journal mode (PRAGMA journal_mode). Defaults to \"WAL\".",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF"),
+				},
+			},
+			"foreign_keys": schema.BoolAttribute{
+				Description: "Enable foreign key enforcement (PRAGMA foreign_keys). Defaults to true.",
+				Optional:    true,
+			},
+			"actor": schema.StringAttribute{
+				Description: "Name recorded in the audit log as the author of every change. May also be provided via PERSONDB_ACTOR environment variable. Defaults to the name of the operating system user.",
+				Optional:    true,
+			},
+			"soft_delete": schema.BoolAttribute{
+				Description: "Mark deleted persons with a tombstone instead of removing them, so they are restored when created again. Not used by the \"http\" backend, where the server decides. Defaults to false.",
+				Optional:    true,
+			},
+			"existing_person_check": schema.StringAttribute{
+				Description: "Severity of the diagnostic reported at plan time when a person planned for creation already exists in the Persons Database: \"error\" or \"warning\". Defaults to \"error\".",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(existingPersonCheckError, existingPersonCheckWarning),
+				},
+			},
+			"adopt_existing": schema.BoolAttribute{
+				Description: "Take over a person that already exists in the Persons Database when it is created, updating it to the planned values, instead of requiring an import. Defaults to false.",
+				Optional:    true,
+			},
+			"max_name_length": schema.Int64Attribute{
+				Description: "Maximum number of characters of each part of the name of a persondb_person, checked at plan time. Defaults to 100.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "Number of times an operation is retried when the Persons Database is busy or locked. Defaults to 5.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.StringAttribute{
+				Description: "Maximum wait between two retries on a busy or locked Persons Database, as a duration string such as \"2s\". Defaults to \"2s\".",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"backend": schema.SingleNestedBlock{
+				Description: "Storage backend for persons. Defaults to the SQLite database in database_filename.",
+				Attributes: map[string]schema.Attribute{
+					"type": schema.StringAttribute{
+						Description: "Backend type: \"sqlite\", \"memory\", \"json\" or \"http\". The memory backend does not persist data between Terraform runs. Defaults to \"sqlite\".",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(backendSQLite, backendMemory, backendJSON, backendHTTP),
+						},
+					},
+					"path": schema.StringAttribute{
+						Description: "Path of the JSON file used by the \"json\" backend.",
+						Optional:    true,
+					},
+					"endpoint": schema.StringAttribute{
+						Description: "URL of the persondb server used by the \"http\" backend, e.g. \"http://localhost:8080\".",
+						Optional:    true,
+					},
+					"token": schema.StringAttribute{
+						Description: "Bearer token for the persondb server used by the \"http\" backend. May also be provided via PERSONDB_TOKEN environment variable.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"request_timeout": schema.StringAttribute{
+						Description: "Timeout of a request to the persondb server used by the \"http\" backend, as a duration string such as \"30s\". \"0s\" disables the timeout. Defaults to \"30s\".",
+						Optional:    true,
+					},
+				},
+			},
+			"default_tags": schema.SingleNestedBlock{
+				Description: "Tags merged into the tags of every persondb_person, resource tags with the same key override them.",
+				Attributes: map[string]schema.Attribute{
+					"tags": schema.MapAttribute{
+						Description: "Default tags, such as owner or workspace.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.Map{
+							mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+				},
+			},
+			"default_timeouts": schema.SingleNestedBlock{
+				Description: "Default timeouts of the persondb_person operations, used when the timeouts block of the resource does not set them. An operation waiting on a locked SQLite database notices the timeout when busy_timeout expires.",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						Description: "Timeout of creating a person, as a duration string such as \"5m\". Defaults to \"20m\".",
+						Optional:    true,
+					},
+					"read": schema.StringAttribute{
+						Description: "Timeout of reading a person, as a duration string such as \"5m\". Defaults to \"20m\".",
+						Optional:    true,
+					},
+					"update": schema.StringAttribute{
+						Description: "Timeout of updating a person, as a duration string such as \"5m\". Defaults to \"20m\".",
+						Optional:    true,
+					},
+					"delete": schema.StringAttribute{
+						Description: "Timeout of deleting a person, as a duration string such as \"5m\". Defaults to \"20m\".",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -79,10 +328,200 @@ func (p *persondbProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if config.MaxOpenConnections.IsUnknown() || config.MaxIdleConnections.IsUnknown() ||
+		config.ConnectionMaxLifetime.IsUnknown() || config.BusyTimeout.IsUnknown() ||
+		config.JournalMode.IsUnknown() || config.ForeignKeys.IsUnknown() ||
+		config.MaxRetries.IsUnknown() || config.RetryMaxWait.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Persons Database connection settings",
+			"The provider cannot create the Persons DB API client as there is an unknown configuration value for one of the connection settings. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Actor.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("actor"),
+			"Unknown audit actor",
+			"The provider cannot create the Persons DB API client as there is an unknown configuration value for the actor. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the PERSONDB_ACTOR environment variable.",
+		)
+	}
+
+	if config.SoftDelete.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown soft delete settings",
+			"The provider cannot create the Persons DB API client as there is an unknown configuration value for the soft delete settings. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ExistingPersonCheck.IsUnknown() || config.AdoptExisting.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown existing person settings",
+			"The provider cannot create the Persons DB API client as there is an unknown configuration value for the existing person settings. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxNameLength.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_name_length"),
+			"Unknown maximum name length",
+			"The provider cannot create the Persons DB API client as there is an unknown configuration value for the maximum name length. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.Backend != nil && (config.Backend.Type.IsUnknown() || config.Backend.Path.IsUnknown() ||
+		config.Backend.Endpoint.IsUnknown() || config.Backend.Token.IsUnknown() ||
+		config.Backend.RequestTimeout.IsUnknown()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("backend"),
+			"Unknown Persons Database backend",
+			"The provider cannot create the Persons DB API client as there is an unknown configuration value for the backend. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DefaultTimeouts != nil && (config.DefaultTimeouts.Create.IsUnknown() || config.DefaultTimeouts.Read.IsUnknown() ||
+		config.DefaultTimeouts.Update.IsUnknown() || config.DefaultTimeouts.Delete.IsUnknown()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_timeouts"),
+			"Unknown default timeouts",
+			"The provider cannot create the Persons DB API client as there is an unknown configuration value for the default timeouts. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DefaultTags != nil && !isKnownMap(config.DefaultTags.Tags) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_tags"),
+			"Unknown default tags",
+			"The provider cannot create the Persons DB API client as there is an unknown configuration value for the default tags. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	backendType := backendSQLite
+	if config.Backend != nil && !config.Backend.Type.IsNull() {
+		backendType = config.Backend.Type.ValueString()
+	}
+
+	options := persondbclient.Options{
+		SoftDelete: config.SoftDelete.ValueBool(),
+	}
+
+	timeouts := persondbTimeouts{
+		create: defaultTimeout,
+		read:   defaultTimeout,
+		update: defaultTimeout,
+		delete: defaultTimeout,
+	}
+	if config.DefaultTimeouts != nil {
+		timeoutsPath := path.Root("default_timeouts")
+		if !config.DefaultTimeouts.Create.IsNull() {
+			timeouts.create = parseDuration(config.DefaultTimeouts.Create, timeoutsPath.AtName("create"), resp)
+		}
+		if !config.DefaultTimeouts.Read.IsNull() {
+			timeouts.read = parseDuration(config.DefaultTimeouts.Read, timeoutsPath.AtName("read"), resp)
+		}
+		if !config.DefaultTimeouts.Update.IsNull() {
+			timeouts.update = parseDuration(config.DefaultTimeouts.Update, timeoutsPath.AtName("update"), resp)
+		}
+		if !config.DefaultTimeouts.Delete.IsNull() {
+			timeouts.delete = parseDuration(config.DefaultTimeouts.Delete, timeoutsPath.AtName("delete"), resp)
+		}
+	}
+
+	var defaultTags map[string]string
+	if config.DefaultTags != nil {
+		resp.Diagnostics.Append(config.DefaultTags.Tags.ElementsAs(ctx, &defaultTags, false)...)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var client persondbclient.PersonStore
+	switch backendType {
+	case backendMemory:
+		client = persondbclient.NewMemoryStore(options)
+	case backendJSON:
+		client = p.configureJSONFileStore(config, options, resp)
+	case backendHTTP:
+		client = p.configureHTTPStore(config, resp)
+	default:
+		client = p.configureSQLiteStore(ctx, config, options, resp)
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	p.closeClient(ctx)
+	p.client = client
+
+	// Default values to environment variables, but override
+	// with Terraform configuration value if set.
+	actor := os.Getenv("PERSONDB_ACTOR")
+
+	if !config.Actor.IsNull() {
+		actor = config.Actor.ValueString()
+	}
+
+	if actor == "" {
+		if currentUser, err := user.Current(); err == nil {
+			actor = currentUser.Username
+		}
+	}
+
+	existingPersonCheck := existingPersonCheckError
+	if !config.ExistingPersonCheck.IsNull() {
+		existingPersonCheck = config.ExistingPersonCheck.ValueString()
+	}
+
+	maxNameLength := int64(defaultMaxNameLength)
+	if !config.MaxNameLength.IsNull() {
+		maxNameLength = config.MaxNameLength.ValueInt64()
+	}
+
+	providerData := &persondbProviderData{
+		client:              client,
+		actor:               actor,
+		existingPersonCheck: existingPersonCheck,
+		adoptExisting:       config.AdoptExisting.ValueBool(),
+		timeouts:            timeouts,
+		defaultTags:         defaultTags,
+		maxNameLength:       maxNameLength,
+	}
+
+	// Make the Persons DB API client available during DataSource, Resource, ListResource
+	// and Action type Configure methods.
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.ListResourceData = providerData
+	resp.ActionData = providerData
+}
+
+// closeClient closes the store opened by a previous Configure call.
+func (p *persondbProvider) closeClient(ctx context.Context) {
+	if p.client == nil {
+		return
+	}
+	if err := p.client.Close(); err != nil {
+		tflog.Warn(ctx, "Unable to close the Persons DB API client", map[string]any{"error": err.Error()})
+	}
+	p.client = nil
+}
+
+// configureSQLiteStore creates the SQLite backend from the provider
+// configuration.
+func (p *persondbProvider) configureSQLiteStore(ctx context.Context, config persondbProviderModel, options persondbclient.Options, resp *provider.ConfigureResponse) persondbclient.PersonStore {
 	// Default values to environment variables, but override
 	// with Terraform configuration value if set.
 	database := os.Getenv("CUSTOM_DATABASE_FILENAME")
@@ -99,13 +538,51 @@ func (p *persondbProvider) Configure(ctx context.Context, req provider.Configure
 				"Set the database_filename value in the configuration or use the CUSTOM_DATABASE_FILENAME environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
+		return nil
+	}
+
+	clientConfig := persondbclient.DefaultConfig(database)
+	clientConfig.Options = options
+	if !config.MaxOpenConnections.IsNull() {
+		clientConfig.MaxOpenConnections = int(config.MaxOpenConnections.ValueInt64())
+	}
+	if !config.MaxIdleConnections.IsNull() {
+		clientConfig.MaxIdleConnections = int(config.MaxIdleConnections.ValueInt64())
+	}
+	if !config.ConnectionMaxLifetime.IsNull() {
+		clientConfig.ConnectionMaxLifetime = parseDuration(config.ConnectionMaxLifetime, path.Root("connection_max_lifetime"), resp)
+	}
+	if !config.BusyTimeout.IsNull() {
+		clientConfig.BusyTimeout = parseDuration(config.BusyTimeout, path.Root("busy_timeout"), resp)
+	}
+	if !config.JournalMode.IsNull() {
+		clientConfig.JournalMode = config.JournalMode.ValueString()
+	}
+	if !config.ForeignKeys.IsNull() {
+		clientConfig.ForeignKeys = config.ForeignKeys.ValueBool()
+	}
+	if !config.MaxRetries.IsNull() {
+		clientConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+	if !config.RetryMaxWait.IsNull() {
+		clientConfig.RetryMaxWait = parseDuration(config.RetryMaxWait, path.Root("retry_max_wait"), resp)
 	}
 
 	if resp.Diagnostics.HasError() {
-		return
+		return nil
 	}
 
-	client, err := persondbclient.NewClient(database)
+	client, err := persondbclient.NewClient(ctx, clientConfig)
+	if errors.Is(err, persondbclient.ErrSchemaTooNew) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("database_filename"),
+			"Persons Database Schema Too New",
+			"The Persons Database was written by a newer version of the persondb provider than the one currently running. "+
+				"Upgrade the provider to at least the version that last wrote the database.\n\n"+
+				"Persons DB API Client Error: "+err.Error(),
+		)
+		return nil
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Create Persons DB API Client",
@@ -113,19 +590,99 @@ func (p *persondbProvider) Configure(ctx context.Context, req provider.Configure
 				"If the error is not clear, please contact the provider developers.\n\n"+
 				"Persons DB API Client Error: "+err.Error(),
 		)
-		return
+		return nil
+	}
+	return client
+}
+
+// configureJSONFileStore creates the JSON file backend from the provider
+// configuration.
+func (p *persondbProvider) configureJSONFileStore(config persondbProviderModel, options persondbclient.Options, resp *provider.ConfigureResponse) persondbclient.PersonStore {
+	if config.Backend.Path.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("backend").AtName("path"),
+			"Missing Persons JSON filename",
+			"The provider cannot create the Persons DB API client as there is a missing or empty value for the backend path. "+
+				"Set the path of the JSON file when using the \""+backendJSON+"\" backend.",
+		)
+		return nil
 	}
 
-	// Make the Persons DB API client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = client
-	resp.ResourceData = client
+	client, err := persondbclient.NewJSONFileStore(config.Backend.Path.ValueString(), options)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Persons DB API Client",
+			"An unexpected error occurred when creating the Persons DB API Client. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"Persons DB API Client Error: "+err.Error(),
+		)
+		return nil
+	}
+	return client
+}
+
+// configureHTTPStore creates the HTTP backend from the provider
+// configuration.
+func (p *persondbProvider) configureHTTPStore(config persondbProviderModel, resp *provider.ConfigureResponse) persondbclient.PersonStore {
+	if config.Backend.Endpoint.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("backend").AtName("endpoint"),
+			"Missing persondb server endpoint",
+			"The provider cannot create the Persons DB API client as there is a missing or empty value for the backend endpoint. "+
+				"Set the URL of the persondb server when using the \""+backendHTTP+"\" backend.",
+		)
+		return nil
+	}
+
+	// Default values to environment variables, but override
+	// with Terraform configuration value if set.
+	token := os.Getenv("PERSONDB_TOKEN")
+
+	if !config.Backend.Token.IsNull() {
+		token = config.Backend.Token.ValueString()
+	}
+
+	timeout := persondbclient.DefaultHTTPTimeout
+	if !config.Backend.RequestTimeout.IsNull() {
+		timeout = parseDuration(config.Backend.RequestTimeout, path.Root("backend").AtName("request_timeout"), resp)
+		if resp.Diagnostics.HasError() {
+			return nil
+		}
+	}
+
+	client, err := persondbclient.NewHTTPStore(config.Backend.Endpoint.ValueString(), token, timeout)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("backend").AtName("endpoint"),
+			"Invalid persondb server endpoint",
+			"The provider cannot create the Persons DB API client as the backend endpoint is not valid: "+err.Error(),
+		)
+		return nil
+	}
+	return client
+}
+
+// parseDuration parses a duration string provider attribute and records an
+// attribute error on the response when the value is invalid.
+func parseDuration(value types.String, attributePath path.Path, resp *provider.ConfigureResponse) time.Duration {
+	duration, err := time.ParseDuration(value.ValueString())
+	if err != nil || duration < 0 {
+		resp.Diagnostics.AddAttributeError(
+			attributePath,
+			"Invalid duration",
+			"Expected a non-negative duration string such as \"5s\" or \"30m\", got: "+value.ValueString(),
+		)
+		return 0
+	}
+	return duration
 }
 
 // DataSources defines the data sources implemented in the provider.
 func (p *persondbProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewPersonDataSource,
+		NewPersonAuditDataSource,
+		NewPersonsDataSource,
 	}
 }
 
@@ -135,3 +692,53 @@ func (p *persondbProvider) Resources(_ context.Context) []func() resource.Resour
 		NewPersonResource,
 	}
 }
+
+// ListResources defines the list resources implemented in the provider.
+func (p *persondbProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewPersonListResource,
+	}
+}
+
+// Actions defines the actions implemented in the provider.
+func (p *persondbProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewPurgeDeletedPersonsAction,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *persondbProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewParseIDFunction,
+		NewFormatIDFunction,
+		NewFullNameFunction,
+	}
+}
+
+// isKnownMap reports whether the map and all of its elements are known.
+func isKnownMap(value types.Map) bool {
+	if value.IsUnknown() {
+		return false
+	}
+	for _, element := range value.Elements() {
+		if element.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
+
+// isKnownObject reports whether the object and all of its attributes are
+// known.
+func isKnownObject(value types.Object) bool {
+	if value.IsUnknown() {
+		return false
+	}
+	for _, attribute := range value.Attributes() {
+		if attribute.IsUnknown() {
+			return false
+		}
+	}
+	return true
+}
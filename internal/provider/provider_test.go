@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// configureTestProvider configures the provider with a SQLite database file
+// and returns the provider data handed to the resources.
+func configureTestProvider(t *testing.T, p *persondbProvider, databaseFilename string) *persondbProviderData {
+	t.Helper()
+	ctx := context.Background()
+
+	var schemaResp provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Plan{Schema: schemaResp.Schema}
+	diags := config.Set(ctx, &persondbProviderModel{
+		Database: types.StringValue(databaseFilename),
+		Actor:    types.StringValue("tester"),
+	})
+	if diags.HasError() {
+		t.Fatalf("building provider config: %v", diags)
+	}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config.Raw}}, &resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Configure: %v", resp.Diagnostics)
+	}
+	return resp.ResourceData.(*persondbProviderData)
+}
+
+func TestProviderConfigureClosesPreviousClient(t *testing.T) {
+	ctx := context.Background()
+	databaseFilename := filepath.Join(t.TempDir(), "persons.db")
+	p := &persondbProvider{}
+	t.Cleanup(func() { p.closeClient(ctx) })
+
+	first := configureTestProvider(t, p, databaseFilename)
+	if _, err := first.client.CheckPersonExists(ctx, "1"); err != nil {
+		t.Fatalf("CheckPersonExists: %v", err)
+	}
+
+	second := configureTestProvider(t, p, databaseFilename)
+	if _, err := first.client.CheckPersonExists(ctx, "1"); err == nil {
+		t.Error("CheckPersonExists on the store of the previous configuration: expected an error, the store must be closed")
+	}
+	if _, err := second.client.CheckPersonExists(ctx, "1"); err != nil {
+		t.Errorf("CheckPersonExists on the store of the latest configuration: %v", err)
+	}
+}
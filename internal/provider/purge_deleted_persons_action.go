@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ action.Action              = &PurgeDeletedPersonsAction{}
+	_ action.ActionWithConfigure = &PurgeDeletedPersonsAction{}
+)
+
+// NewPurgeDeletedPersonsAction is a helper function to simplify the provider implementation.
+func NewPurgeDeletedPersonsAction() action.Action {
+	return &PurgeDeletedPersonsAction{}
+}
+
+// PurgeDeletedPersonsAction is the action implementation.
+type PurgeDeletedPersonsAction struct {
+	client persondbclient.PersonStore
+	actor  string
+}
+
+// PurgeDeletedPersonsActionModel maps the action schema data.
+type PurgeDeletedPersonsActionModel struct {
+	OlderThan types.String `tfsdk:"older_than"`
+}
+
+// Metadata returns the action type name.
+func (a *PurgeDeletedPersonsAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_purge_deleted_persons"
+}
+
+// Schema defines the schema for the action.
+func (a *PurgeDeletedPersonsAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Permanently removes the soft delete tombstones of persons deleted longer ago than older_than. " +
+			"Each removed person is recorded as a \"purge\" operation in the audit log.",
+		Attributes: map[string]schema.Attribute{
+			"older_than": schema.StringAttribute{
+				Description: "Remove the tombstones of persons deleted longer ago than this duration, such as \"720h\".",
+				Required:    true,
+			},
+		},
+	}
+}
+
+// Invoke removes the tombstones.
+func (a *PurgeDeletedPersonsAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data PurgeDeletedPersonsActionModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	olderThan, err := time.ParseDuration(data.OlderThan.ValueString())
+	if err != nil || olderThan < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("older_than"),
+			"Invalid duration",
+			"Expected a non-negative duration string such as \"720h\", got: "+data.OlderThan.ValueString(),
+		)
+		return
+	}
+
+	// Record the configured actor in the audit log
+	ctx = persondbclient.ContextWithActor(ctx, a.actor)
+
+	purged, err := a.client.PurgeDeletedPersons(ctx, time.Now().Add(-olderThan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to purge deleted persons",
+			"Persons DB API Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Info(ctx, "Purged deleted persons", map[string]any{"purged": purged})
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Purged %d deleted persons", purged),
+	})
+}
+
+// Configure adds the provider configured client to the action.
+func (a *PurgeDeletedPersonsAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*persondbProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *persondbProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	a.client = providerData.client
+	a.actor = providerData.actor
+}
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestPersonIDValidator(t *testing.T) {
+	testCases := map[string]struct {
+		personID  string
+		wantError bool
+	}{
+		"plain":       {personID: "1"},
+		"empty":       {personID: "", wantError: true},
+		"slash":       {personID: "1/2", wantError: true},
+		"resource ID": {personID: "/person/1", wantError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("person_id"),
+				ConfigValue: types.StringValue(testCase.personID),
+			}
+			var resp validator.StringResponse
+			personIDValidator{}.ValidateString(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() != testCase.wantError {
+				t.Errorf("got diagnostics %v, want error %t", resp.Diagnostics, testCase.wantError)
+			}
+
+			// A person ID accepted at plan time can be imported again
+			_, err := parsePersonID(testCase.personID)
+			if !testCase.wantError && err != nil {
+				t.Errorf("parsePersonID: unexpected error %v", err)
+			}
+		})
+	}
+}
+
+func TestEmailRegexp(t *testing.T) {
+	testCases := map[string]struct {
+		email string
+		want  bool
+	}{
+		"plain":          {email: "jan.peeters@example.com", want: true},
+		"subdomain":      {email: "jan+tag@mail.example.be", want: true},
+		"empty":          {email: ""},
+		"no at":          {email: "jan.peeters.example.com"},
+		"two ats":        {email: "jan@peeters@example.com"},
+		"no domain dot":  {email: "jan@localhost"},
+		"no local part":  {email: "@example.com"},
+		"space":          {email: "jan peeters@example.com"},
+		"trailing space": {email: "jan@example.com "},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := emailRegexp.MatchString(testCase.email); got != testCase.want {
+				t.Errorf("got %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestPhoneNumberRegexp(t *testing.T) {
+	testCases := map[string]struct {
+		phoneNumber string
+		want        bool
+	}{
+		"international":    {phoneNumber: "+32 470 12 34 56", want: true},
+		"slashes and dots": {phoneNumber: "0470/12.34.56"},
+		"dashes":           {phoneNumber: "0470-12-34-56", want: true},
+		"area code":        {phoneNumber: "(03) 123 45 67", want: true},
+		"empty":            {phoneNumber: ""},
+		"too short":        {phoneNumber: "12"},
+		"letters":          {phoneNumber: "+32 470 CALL ME"},
+		"plus only":        {phoneNumber: "+"},
+		"too long":         {phoneNumber: "+" + strings.Repeat("1", 32)},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if got := phoneNumberRegexp.MatchString(testCase.phoneNumber); got != testCase.want {
+				t.Errorf("got %t, want %t", got, testCase.want)
+			}
+		})
+	}
+}
+
+func TestDateValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"date":          {value: types.StringValue("1990-12-31")},
+		"leap day":      {value: types.StringValue("2000-02-29")},
+		"null":          {value: types.StringNull()},
+		"unknown":       {value: types.StringUnknown()},
+		"no leap day":   {value: types.StringValue("2001-02-29"), wantError: true},
+		"day first":     {value: types.StringValue("31-12-1990"), wantError: true},
+		"timestamp":     {value: types.StringValue("1990-12-31T00:00:00Z"), wantError: true},
+		"no zero pad":   {value: types.StringValue("1990-1-2"), wantError: true},
+		"empty":         {value: types.StringValue(""), wantError: true},
+		"invalid month": {value: types.StringValue("1990-13-01"), wantError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("birth_date"),
+				ConfigValue: testCase.value,
+			}
+			var resp validator.StringResponse
+			dateValidator{}.ValidateString(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() != testCase.wantError {
+				t.Errorf("got diagnostics %v, want error %t", resp.Diagnostics, testCase.wantError)
+			}
+		})
+	}
+}
+
+func TestCountryCodeValidator(t *testing.T) {
+	testCases := map[string]struct {
+		value     types.String
+		wantError bool
+	}{
+		"country code": {value: types.StringValue("BE")},
+		"null":         {value: types.StringNull()},
+		"unknown":      {value: types.StringUnknown()},
+		"lower case":   {value: types.StringValue("be"), wantError: true},
+		"alpha-3":      {value: types.StringValue("BEL"), wantError: true},
+		"unassigned":   {value: types.StringValue("XX"), wantError: true},
+		"empty":        {value: types.StringValue(""), wantError: true},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := validator.StringRequest{
+				Path:        path.Root("address").AtListIndex(0).AtName("country"),
+				ConfigValue: testCase.value,
+			}
+			var resp validator.StringResponse
+			countryCodeValidator{}.ValidateString(context.Background(), req, &resp)
+			if resp.Diagnostics.HasError() != testCase.wantError {
+				t.Errorf("got diagnostics %v, want error %t", resp.Diagnostics, testCase.wantError)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Package server exposes a PersonStore over the persondb JSON REST API, the
+// API consumed by the provider's "http" backend.
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+// Server serves the persons REST API on top of a PersonStore.
+type Server struct {
+	store persondbclient.PersonStore
+	token string
+	actor string
+	mux   *http.ServeMux
+}
+
+// New returns a Server for store. When token is not empty every request must
+// carry it as a bearer token. The changes are recorded in the audit log with
+// actor as their author, the only one the server can vouch for.
+func New(store persondbclient.PersonStore, token, actor string) *Server {
+	s := &Server{
+		store: store,
+		token: token,
+		actor: actor,
+		mux:   http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /v1/persons", s.listPersons)
+	s.mux.HandleFunc("POST /v1/persons", s.createPerson)
+	s.mux.HandleFunc("GET /v1/persons/{person_id}", s.readPerson)
+	s.mux.HandleFunc("PUT /v1/persons/{person_id}", s.updatePerson)
+	s.mux.HandleFunc("DELETE /v1/persons/{person_id}", s.deletePerson)
+	s.mux.HandleFunc("GET /v1/persons/{person_id}/audit", s.listAuditEntries)
+	s.mux.HandleFunc("POST /v1/purge", s.purgeDeletedPersons)
+	return s
+}
+
+// ServeHTTP authenticates the request and dispatches it to the API handlers.
+// Anyone holding the token can send any actor header, so it is only recorded
+// as the claimed actor of the changes in the audit log.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if s.token != "" {
+		expected := "Bearer " + s.token
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte(expected)) != 1 {
+			writeError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid bearer token")
+			return
+		}
+	}
+	ctx := persondbclient.ContextWithActor(r.Context(), s.actor)
+	if actor := r.Header.Get(persondbclient.ActorHeader); actor != "" {
+		ctx = persondbclient.ContextWithClaimedActor(ctx, actor)
+	}
+	r = r.WithContext(ctx)
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) listPersons(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := persondbclient.ListFilter{
+		LastNamePrefix: query.Get("last_name_prefix"),
+		FirstName:      query.Get("first_name"),
+		PersonIDs:      query["person_id"],
+		OrderBy:        query.Get("order_by"),
+	}
+	for _, tag := range query["tag"] {
+		key, value, found := strings.Cut(tag, "=")
+		if !found {
+			writeError(w, http.StatusBadRequest, "invalid_filter", "invalid tag, expected key=value: "+tag)
+			return
+		}
+		if filter.Tags == nil {
+			filter.Tags = map[string]string{}
+		}
+		filter.Tags[key] = value
+	}
+	var err error
+	if value := query.Get("descending"); value != "" {
+		if filter.Descending, err = strconv.ParseBool(value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_filter", "invalid descending: "+value)
+			return
+		}
+	}
+	if value := query.Get("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_filter", "invalid limit: "+value)
+			return
+		}
+	}
+	if value := query.Get("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_filter", "invalid offset: "+value)
+			return
+		}
+	}
+
+	persons, err := s.store.ListPersons(r.Context(), filter)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	if persons == nil {
+		persons = []persondbclient.Person{}
+	}
+	writeJSON(w, http.StatusOK, map[string][]persondbclient.Person{
+		"persons": persons,
+	})
+}
+
+func (s *Server) createPerson(w http.ResponseWriter, r *http.Request) {
+	var person persondbclient.Person
+	if !decodePerson(w, r, &person) {
+		return
+	}
+	if person.PersonID == "" {
+		writeError(w, http.StatusBadRequest, "invalid_request", "person_id is required")
+		return
+	}
+	created, err := s.store.CreatePerson(r.Context(), person)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, created)
+}
+
+func (s *Server) readPerson(w http.ResponseWriter, r *http.Request) {
+	person, err := s.store.ReadPerson(r.Context(), r.PathValue("person_id"))
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, person)
+}
+
+func (s *Server) updatePerson(w http.ResponseWriter, r *http.Request) {
+	var person persondbclient.Person
+	if !decodePerson(w, r, &person) {
+		return
+	}
+	// The person_id in the path is authoritative.
+	person.PersonID = r.PathValue("person_id")
+	updated, err := s.store.UpdatePerson(r.Context(), person)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, updated)
+}
+
+func (s *Server) deletePerson(w http.ResponseWriter, r *http.Request) {
+	if err := s.store.DeletePerson(r.Context(), r.PathValue("person_id")); err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listAuditEntries(w http.ResponseWriter, r *http.Request) {
+	entries, err := s.store.ListAuditEntries(r.Context(), r.PathValue("person_id"))
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	if entries == nil {
+		entries = []persondbclient.AuditEntry{}
+	}
+	writeJSON(w, http.StatusOK, map[string][]persondbclient.AuditEntry{
+		"entries": entries,
+	})
+}
+
+func (s *Server) purgeDeletedPersons(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		DeletedBefore time.Time `json:"deleted_before"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "invalid JSON body: "+err.Error())
+		return
+	}
+	purged, err := s.store.PurgeDeletedPersons(r.Context(), request.DeletedBefore)
+	if err != nil {
+		writeStoreError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]int64{
+		"purged": purged,
+	})
+}
+
+// decodePerson reads the JSON request body, it writes a 400 response and
+// returns false when the body is invalid.
+func decodePerson(w http.ResponseWriter, r *http.Request, person *persondbclient.Person) bool {
+	if err := json.NewDecoder(r.Body).Decode(person); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", "invalid JSON body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeStoreError maps a PersonStore error onto an HTTP status and error code.
+func writeStoreError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, persondbclient.ErrPersonNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, persondbclient.ErrPersonAlreadyExists), errors.Is(err, persondbclient.ErrVersionConflict),
+		errors.Is(err, persondbclient.ErrEmailAlreadyExists):
+		status = http.StatusConflict
+	case errors.Is(err, persondbclient.ErrInvalidFilter):
+		status = http.StatusBadRequest
+	case errors.Is(err, persondbclient.ErrDatabaseLocked):
+		status = http.StatusServiceUnavailable
+	}
+	writeError(w, status, persondbclient.ErrorCode(err), err.Error())
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]persondbclient.APIError{
+		"error": {Code: code, Message: message},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,200 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	persondbclient "github.com/wim-vdw/terraform-provider-persondb-plugin-framework/internal/client"
+)
+
+const (
+	testToken = "secret"
+	testActor = "api"
+)
+
+// newTestHTTPStore returns an HTTP backend talking to a server on top of an
+// empty in-memory store.
+func newTestHTTPStore(t *testing.T, token string) *persondbclient.HTTPStore {
+	t.Helper()
+	srv := httptest.NewServer(New(persondbclient.NewMemoryStore(persondbclient.Options{}), testToken, testActor))
+	t.Cleanup(srv.Close)
+	store, err := persondbclient.NewHTTPStore(srv.URL, token, persondbclient.DefaultHTTPTimeout)
+	if err != nil {
+		t.Fatalf("NewHTTPStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestHTTPStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := newTestHTTPStore(t, testToken)
+
+	created, err := store.CreatePerson(ctx, persondbclient.Person{
+		PersonID:     "1",
+		LastName:     "Peeters",
+		FirstName:    "Jan",
+		PhoneNumbers: []string{"+32 470 12 34 56"},
+		Tags:         map[string]string{"team": "platform"},
+	})
+	if err != nil {
+		t.Fatalf("CreatePerson: %v", err)
+	}
+	if created.Version != 1 {
+		t.Errorf("CreatePerson: got version %d, want 1", created.Version)
+	}
+
+	read, err := store.ReadPerson(ctx, "1")
+	if err != nil {
+		t.Fatalf("ReadPerson: %v", err)
+	}
+	if read.LastName != "Peeters" || read.FirstName != "Jan" || len(read.PhoneNumbers) != 1 || read.Tags["team"] != "platform" {
+		t.Errorf("ReadPerson: got %+v", read)
+	}
+
+	read.FirstName = "Johannes"
+	updated, err := store.UpdatePerson(ctx, *read)
+	if err != nil {
+		t.Fatalf("UpdatePerson: %v", err)
+	}
+	if updated.FirstName != "Johannes" || updated.Version != 2 {
+		t.Errorf("UpdatePerson: got %+v", updated)
+	}
+
+	persons, err := store.ListPersons(ctx, persondbclient.ListFilter{Tags: map[string]string{"team": "platform"}})
+	if err != nil {
+		t.Fatalf("ListPersons: %v", err)
+	}
+	if len(persons) != 1 || persons[0].PersonID != "1" {
+		t.Errorf("ListPersons: got %+v", persons)
+	}
+
+	if err := store.DeletePerson(ctx, "1"); err != nil {
+		t.Fatalf("DeletePerson: %v", err)
+	}
+	exists, err := store.CheckPersonExists(ctx, "1")
+	if err != nil || exists {
+		t.Errorf("CheckPersonExists after delete: got %v, %v, want false", exists, err)
+	}
+}
+
+func TestHTTPStoreAuditActor(t *testing.T) {
+	ctx := context.Background()
+	store := newTestHTTPStore(t, testToken)
+
+	claimedCtx := persondbclient.ContextWithActor(ctx, "mallory")
+	if _, err := store.CreatePerson(claimedCtx, persondbclient.Person{PersonID: "1", LastName: "Peeters"}); err != nil {
+		t.Fatalf("CreatePerson: %v", err)
+	}
+
+	entries, err := store.ListAuditEntries(ctx, "1")
+	if err != nil {
+		t.Fatalf("ListAuditEntries: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("ListAuditEntries: got %d entries, want 1", len(entries))
+	}
+	if entries[0].Actor != testActor || entries[0].ClaimedActor != "mallory" {
+		t.Errorf("ListAuditEntries: got actor %q claimed %q, want %q claimed %q", entries[0].Actor, entries[0].ClaimedActor, testActor, "mallory")
+	}
+}
+
+func TestHTTPStoreErrors(t *testing.T) {
+	ctx := context.Background()
+	store := newTestHTTPStore(t, testToken)
+
+	if _, err := store.CreatePerson(ctx, persondbclient.Person{PersonID: "1", LastName: "Peeters"}); err != nil {
+		t.Fatalf("CreatePerson: %v", err)
+	}
+
+	testCases := map[string]struct {
+		call func() error
+		want error
+	}{
+		"read not found": {
+			call: func() error {
+				_, err := store.ReadPerson(ctx, "2")
+				return err
+			},
+			want: persondbclient.ErrPersonNotFound,
+		},
+		"update not found": {
+			call: func() error {
+				_, err := store.UpdatePerson(ctx, persondbclient.Person{PersonID: "2", LastName: "Janssens"})
+				return err
+			},
+			want: persondbclient.ErrPersonNotFound,
+		},
+		"delete not found": {
+			call: func() error {
+				return store.DeletePerson(ctx, "2")
+			},
+			want: persondbclient.ErrPersonNotFound,
+		},
+		"create conflict": {
+			call: func() error {
+				_, err := store.CreatePerson(ctx, persondbclient.Person{PersonID: "1", LastName: "Peeters"})
+				return err
+			},
+			want: persondbclient.ErrPersonAlreadyExists,
+		},
+		"version conflict": {
+			call: func() error {
+				_, err := store.UpdatePerson(ctx, persondbclient.Person{PersonID: "1", LastName: "Janssens", Version: 42})
+				return err
+			},
+			want: persondbclient.ErrVersionConflict,
+		},
+		"invalid filter": {
+			call: func() error {
+				_, err := store.ListPersons(ctx, persondbclient.ListFilter{OrderBy: "age"})
+				return err
+			},
+			want: persondbclient.ErrInvalidFilter,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := testCase.call(); !errors.Is(err, testCase.want) {
+				t.Errorf("got error %v, want %v", err, testCase.want)
+			}
+		})
+	}
+}
+
+func TestHTTPStoreUnauthorized(t *testing.T) {
+	store := newTestHTTPStore(t, "wrong")
+
+	_, err := store.ReadPerson(context.Background(), "1")
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("ReadPerson with a wrong token: got error %v, want a 401 error", err)
+	}
+	if errors.Is(err, persondbclient.ErrPersonNotFound) {
+		t.Errorf("ReadPerson with a wrong token: got %v, must not be reported as not found", err)
+	}
+}
+
+func TestHTTPStoreTimeout(t *testing.T) {
+	stalled := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-stalled
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(stalled) })
+
+	store, err := persondbclient.NewHTTPStore(srv.URL, "", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewHTTPStore: %v", err)
+	}
+
+	// No context deadline, like the reads of data sources
+	if _, err := store.ReadPerson(context.Background(), "1"); err == nil {
+		t.Fatal("ReadPerson on a stalled server: expected a timeout error")
+	}
+}
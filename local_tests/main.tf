@@ -11,7 +11,9 @@ provider "persondb" {
 }
 
 resource "persondb_person" "wim" {
-  person_id  = "1"
-  last_name  = "Van den Wyngaert"
-  first_name = "Wim"
+  person_id = "1"
+  name = {
+    given  = "Wim"
+    family = "Van den Wyngaert"
+  }
 }